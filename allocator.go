@@ -0,0 +1,72 @@
+package id
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Allocator hands out blocks of LDIDs for bulk inserts that are guaranteed to be sorted and
+// unique. Starting at a given timestamp, it stores a counter in RandA instead of random data,
+// and advances the timestamp by one millisecond whenever the 4096 counter values are used up.
+// RandB holds random data as usual.
+//
+// Every LDID is larger than all LDIDs previously allocated by the same Allocator, also across
+// calls to AllocN. The timestamps run ahead of the clock when more than 4096 LDIDs per
+// millisecond are allocated, and the counter makes RandA predictable. An Allocator is safe for
+// concurrent use.
+type Allocator struct {
+	// Reader is the source of random data. When nil, crypto/rand.Reader is used.
+	Reader io.Reader
+
+	mu        sync.Mutex
+	timestamp uint64
+	counter   uint64
+}
+
+// NewAllocator creates a new Allocator that starts allocating at the millisecond of t.
+func NewAllocator(t time.Time) *Allocator {
+	return &Allocator{timestamp: uint64(t.UnixMilli())}
+}
+
+// AllocN returns a block of n LDIDs in ascending order. It returns an error if n is negative,
+// if the random data cannot be read or if the timestamp would exceed 48 bits; the Allocator
+// is not advanced on error.
+func (a *Allocator) AllocN(n int) ([]*LDID, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("failed to allocate LDIDs: n must not be negative, got %d", n)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	g := &DefaultGenerator{Reader: a.Reader}
+	timestamp, counter := a.timestamp, a.counter
+	ids := make([]*LDID, n)
+
+	for i := range ids {
+		if counter > 1<<randASize-1 {
+			timestamp++
+			counter = 0
+		}
+
+		if timestamp > 1<<timestampSize-1 {
+			return nil, errors.New("failed to allocate LDIDs: timestamp overflow")
+		}
+
+		randB, err := g.GenerateRandomBits(rand.Reader, int64(randBSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to allocate LDIDs: %w", err)
+		}
+
+		ids[i] = newFromFields(timestamp, counter, randB)
+		counter++
+	}
+
+	a.timestamp, a.counter = timestamp, counter
+
+	return ids, nil
+}
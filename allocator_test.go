@@ -0,0 +1,89 @@
+package id
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestAllocator(t *testing.T) {
+	start := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("Block larger than RandA space", func(t *testing.T) {
+		a := NewAllocator(start)
+
+		ids, err := a.AllocN(3*4096 + 10)
+		if err != nil {
+			t.Fatalf("AllocN() error = %v, wantErr %v", err, false)
+		}
+
+		if len(ids) != 3*4096+10 {
+			t.Fatalf("AllocN() length = %v, want %v", len(ids), 3*4096+10)
+		}
+
+		for i := 1; i < len(ids); i++ {
+			if c, _ := Compare(ids[i-1], ids[i]); c != -1 {
+				t.Fatalf("Compare(ids[%d], ids[%d]) = %v, want %v", i-1, i, c, -1)
+			}
+		}
+
+		if timestamp, _ := ids[4095].Timestamp(); timestamp != uint64(start.UnixMilli()) {
+			t.Fatalf("Timestamp() = %v, want %v", timestamp, start.UnixMilli())
+		}
+
+		if timestamp, _ := ids[len(ids)-1].Timestamp(); timestamp != uint64(start.UnixMilli())+3 {
+			t.Fatalf("Timestamp() = %v, want %v", timestamp, start.UnixMilli()+3)
+		}
+
+		if randA, _ := ids[4096].RandA(); randA != 0 {
+			t.Fatalf("RandA() = %v, want %v", randA, 0)
+		}
+	})
+
+	t.Run("Blocks continue in order", func(t *testing.T) {
+		a := NewAllocator(start)
+
+		first, _ := a.AllocN(4096)
+		second, _ := a.AllocN(1)
+
+		if c, _ := Compare(first[len(first)-1], second[0]); c != -1 {
+			t.Fatalf("Compare() = %v, want %v", c, -1)
+		}
+	})
+
+	t.Run("Reader failure does not advance", func(t *testing.T) {
+		a := NewAllocator(start)
+		a.Reader = bytes.NewReader(make([]byte, 8))
+
+		if _, err := a.AllocN(2); err == nil {
+			t.Fatalf("AllocN() error = %v, wantErr %v", err, true)
+		}
+
+		a.Reader = nil
+		ids, _ := a.AllocN(1)
+
+		if randA, _ := ids[0].RandA(); randA != 0 {
+			t.Fatalf("RandA() = %v, want %v", randA, 0)
+		}
+	})
+
+	t.Run("Timestamp overflow", func(t *testing.T) {
+		a := NewAllocator(time.UnixMilli(1<<48 - 1))
+
+		if _, err := a.AllocN(4097); err == nil {
+			t.Fatalf("AllocN() error = %v, wantErr %v", err, true)
+		}
+	})
+
+	t.Run("Invalid n", func(t *testing.T) {
+		a := NewAllocator(start)
+
+		if ids, err := a.AllocN(0); err != nil || len(ids) != 0 {
+			t.Fatalf("AllocN() = %v, %v, want empty block", ids, err)
+		}
+
+		if _, err := a.AllocN(-1); err == nil {
+			t.Fatalf("AllocN() error = %v, wantErr %v", err, true)
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package id
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// BulkGenerator is implemented by generators that can provide the random data for a whole
+// batch of LDIDs in a single call.
+//
+// NewBatchWithGenerator only takes this bulk path for DefaultGenerator and CSPRNGGenerator,
+// identified by their concrete type, and for a SaltedGenerator wrapping either of them. It
+// calls GenerateRandomBits for each field of every other generator. Their GenerateRandomBytes is promoted into every type that embeds them, and using
+// it for a type that overrides GenerateRandomBits would silently bypass the override.
+type BulkGenerator interface {
+	Generator
+	GenerateRandomBytes(randReader io.Reader, n int) ([]byte, error)
+}
+
+// Compile-time check to ensure DefaultGenerator implements BulkGenerator
+var _ BulkGenerator = &DefaultGenerator{}
+
+// randomBytesPerLDID is the number of random bytes consumed per LDID on the bulk path:
+// 2 bytes for RandA and 8 bytes for RandB, truncated to 12 and 62 bits respectively.
+const randomBytesPerLDID = 10
+
+func (g *DefaultGenerator) GenerateRandomBytes(randReader io.Reader, n int) ([]byte, error) {
+	b := make([]byte, n)
+
+	if _, err := io.ReadFull(g.reader(randReader), b); err != nil {
+		return nil, fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+
+	return b, nil
+}
+
+// NewBatch creates n new LDIDs with the default generator
+func NewBatch(n int) ([]*LDID, error) {
+	return NewBatchWithGenerator(defaultGenerator, n)
+}
+
+// NewBatchWithGenerator creates n new LDIDs with a provided generator. For the generators
+// listed at BulkGenerator, the random data for all LDIDs is requested in one call.
+func NewBatchWithGenerator(g Generator, n int) ([]*LDID, error) {
+	if n < 0 {
+		return nil, errors.New("failed to create batch: n must not be negative")
+	}
+
+	ids := make([]*LDID, n)
+
+	bg, ok := bulkGenerator(g)
+	if !ok {
+		for i := range ids {
+			id, err := NewWithGenerator(g)
+			if err != nil {
+				return nil, err
+			}
+			ids[i] = id
+		}
+
+		return ids, nil
+	}
+
+	rb, err := bg.GenerateRandomBytes(rand.Reader, n*randomBytesPerLDID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rb) != n*randomBytesPerLDID {
+		return nil, fmt.Errorf("failed to create batch: got %d random bytes, want %d", len(rb), n*randomBytesPerLDID)
+	}
+
+	for i := range ids {
+		chunk := rb[i*randomBytesPerLDID : (i+1)*randomBytesPerLDID]
+
+		randA := uint64(binary.BigEndian.Uint16(chunk[0:2]))
+		randB := binary.BigEndian.Uint64(chunk[2:10])
+
+		timestamp, err := generateTimestamp(bg)
+		if err != nil {
+			return nil, err
+		}
+
+		id := newFromFields(timestamp, randA, saltRandB(bg, randB))
+		if err := id.bf.Error(); err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+
+	return ids, nil
+}
+
+// bulkGenerator returns g as a BulkGenerator if NewBatchWithGenerator may take the bulk path
+// for it. See BulkGenerator for why this checks the concrete type instead of the interface.
+func bulkGenerator(g Generator) (BulkGenerator, bool) {
+	switch g := g.(type) {
+	case *DefaultGenerator:
+		return g, true
+	case *CSPRNGGenerator:
+		return g, true
+	case *SaltedGenerator:
+		if _, ok := bulkGenerator(g.generator()); ok {
+			return g, true
+		}
+		return nil, false
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,121 @@
+package id
+
+import (
+	"crypto/rand"
+	"io"
+	"testing"
+)
+
+// Mocks
+
+// countingGenerator implements only the Generator interface and counts the calls for random
+// data.
+type countingGenerator struct {
+	randomBitsCalls int
+}
+
+func (g *countingGenerator) GenerateUnixTimestampMS() uint64 {
+	return defaultGenerator.GenerateUnixTimestampMS()
+}
+
+func (g *countingGenerator) GenerateRandomBits(randReader io.Reader, n int64) (uint64, error) {
+	g.randomBitsCalls++
+	return defaultGenerator.GenerateRandomBits(randReader, n)
+}
+
+// Test functions
+
+func TestNewBatchWithGenerator(t *testing.T) {
+	t.Run("Bulk generator", func(t *testing.T) {
+		r := &countingReader{r: rand.Reader}
+
+		ids, err := NewBatchWithGenerator(&DefaultGenerator{Reader: r}, 10)
+		if err != nil {
+			t.Fatalf("NewBatchWithGenerator() error = %v, wantErr %v", err, false)
+		}
+
+		if len(ids) != 10 {
+			t.Fatalf("NewBatchWithGenerator() len = %v, want %v", len(ids), 10)
+		}
+
+		if r.calls != 1 || r.n != 10*randomBytesPerLDID {
+			t.Fatalf("Read() calls = %v, bytes = %v, want 1 and %v", r.calls, r.n, 10*randomBytesPerLDID)
+		}
+
+		for _, id := range ids {
+			if version, _ := id.Version(); version != 0b0111 {
+				t.Fatalf("Version() = %v, want %v", version, 0b0111)
+			}
+			if variant, _ := id.Variant(); variant != 0b10 {
+				t.Fatalf("Variant() = %v, want %v", variant, 0b10)
+			}
+		}
+	})
+
+	t.Run("Embedded generator override", func(t *testing.T) {
+		m := &MockGenerator{
+			DefaultGenerator: &DefaultGenerator{},
+			GenerateRandomBitsFunc: func(randReader io.Reader, n int64) (uint64, error) {
+				return 0, nil
+			},
+		}
+
+		ids, err := NewBatchWithGenerator(m, 10)
+		if err != nil {
+			t.Fatalf("NewBatchWithGenerator() error = %v, wantErr %v", err, false)
+		}
+
+		for _, id := range ids {
+			randA, _ := id.RandA()
+			randB, _ := id.RandB()
+
+			if randA != 0 || randB != 0 {
+				t.Fatalf("RandA(), RandB() = %v, %v, want 0 and 0 from GenerateRandomBits override", randA, randB)
+			}
+		}
+	})
+
+	t.Run("Generator without bulk support", func(t *testing.T) {
+		g := &countingGenerator{}
+
+		ids, err := NewBatchWithGenerator(g, 10)
+		if err != nil {
+			t.Fatalf("NewBatchWithGenerator() error = %v, wantErr %v", err, false)
+		}
+
+		if len(ids) != 10 {
+			t.Fatalf("NewBatchWithGenerator() len = %v, want %v", len(ids), 10)
+		}
+
+		if g.randomBitsCalls != 20 {
+			t.Fatalf("GenerateRandomBits() calls = %v, want %v", g.randomBitsCalls, 20)
+		}
+	})
+
+	t.Run("Bulk generator failing", func(t *testing.T) {
+		if _, err := NewBatchWithGenerator(&DefaultGenerator{Reader: &MockRandomReader{}}, 10); err == nil {
+			t.Fatalf("NewBatchWithGenerator() error = %v, wantErr true", err)
+		}
+	})
+
+	t.Run("Negative n", func(t *testing.T) {
+		if _, err := NewBatchWithGenerator(defaultGenerator, -1); err == nil {
+			t.Fatalf("NewBatchWithGenerator() error = %v, wantErr true", err)
+		}
+	})
+}
+
+func TestNewBatch(t *testing.T) {
+	ids, err := NewBatch(100)
+	if err != nil {
+		t.Fatalf("NewBatch() error = %v, wantErr %v", err, false)
+	}
+
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		if seen[id.String()] {
+			t.Fatalf("NewBatch() produced duplicate %v", id)
+		}
+		seen[id.String()] = true
+	}
+}
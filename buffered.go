@@ -0,0 +1,121 @@
+package id
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// ErrGeneratorClosed is returned by a BufferedGenerator after Close has been called.
+var ErrGeneratorClosed = errors.New("generator closed")
+
+// bufferedReadSize is the number of random bytes the filler goroutine reads at a time.
+const bufferedReadSize = 512
+
+// BufferedGenerator is a Generator that takes its random data from a buffer of 64-bit words
+// filled ahead of time by a background goroutine, moving the crypto/rand read off the critical
+// path of creating an LDID. The randReader passed to GenerateRandomBits is ignored.
+//
+// The goroutine is started by NewBufferedGenerator and runs until Close is called, so Close
+// must be called when the generator is no longer needed to avoid leaking it. After Close,
+// GenerateRandomBits returns ErrGeneratorClosed. A BufferedGenerator is safe for concurrent use.
+type BufferedGenerator struct {
+	values  chan uint64
+	done    chan struct{}
+	stopped chan struct{}
+	once    sync.Once
+	// err is the error that stopped the filler goroutine, set before values is closed.
+	err error
+}
+
+// NewBufferedGenerator creates a new BufferedGenerator buffering bufSize 64-bit words of random
+// data read from crypto/rand.Reader, and starts its filler goroutine. A bufSize below 1 is
+// treated as 1.
+func NewBufferedGenerator(bufSize int) *BufferedGenerator {
+	return newBufferedGenerator(rand.Reader, bufSize)
+}
+
+// newBufferedGenerator creates a new BufferedGenerator reading from r.
+func newBufferedGenerator(r io.Reader, bufSize int) *BufferedGenerator {
+	if bufSize < 1 {
+		bufSize = 1
+	}
+
+	g := &BufferedGenerator{
+		values:  make(chan uint64, bufSize),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+
+	go g.fill(r)
+
+	return g
+}
+
+// fill reads random data from r into the buffer until Close is called or r fails.
+func (g *BufferedGenerator) fill(r io.Reader) {
+	defer close(g.stopped)
+	defer close(g.values)
+
+	buf := make([]byte, bufferedReadSize)
+
+	for {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			g.err = fmt.Errorf("failed to generate random bits: %w", err)
+			return
+		}
+
+		for i := 0; i < len(buf); i += 8 {
+			select {
+			case g.values <- binary.BigEndian.Uint64(buf[i : i+8]):
+			case <-g.done:
+				return
+			}
+		}
+	}
+}
+
+func (g *BufferedGenerator) GenerateUnixTimestampMS() uint64 {
+	return uint64(time.Now().UnixMilli())
+}
+
+func (g *BufferedGenerator) GenerateRandomBits(randReader io.Reader, n int64) (uint64, error) {
+	if n <= 0 || n > 64 {
+		return 0, fmt.Errorf("failed to generate random bits: n must be between 1 and 64, got %d", n)
+	}
+
+	select {
+	case <-g.done:
+		return 0, ErrGeneratorClosed
+	default:
+	}
+
+	v, ok := <-g.values
+	if !ok {
+		if g.err != nil {
+			return 0, g.err
+		}
+		return 0, ErrGeneratorClosed
+	}
+
+	if n == 64 {
+		return v, nil
+	}
+
+	return v & (1<<uint64(n) - 1), nil
+}
+
+// Close stops the filler goroutine and waits for it to exit. It is safe to call more than once
+// and always returns nil.
+func (g *BufferedGenerator) Close() error {
+	g.once.Do(func() {
+		close(g.done)
+	})
+	<-g.stopped
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package id
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBufferedGenerator(t *testing.T) {
+	t.Run("Drain and close", func(t *testing.T) {
+		g := NewBufferedGenerator(16)
+
+		seen := make(map[string]bool)
+		for i := 0; i < 10000; i++ {
+			ldid, err := NewWithGenerator(g)
+			if err != nil {
+				t.Fatalf("NewWithGenerator() error = %v, wantErr %v", err, false)
+			}
+
+			if err := ldid.validate(); err != nil {
+				t.Fatalf("NewWithGenerator() = %v, want valid LDID: %v", ldid, err)
+			}
+
+			if seen[ldid.String()] {
+				t.Fatalf("NewWithGenerator() = %v, want unique LDIDs", ldid)
+			}
+			seen[ldid.String()] = true
+		}
+
+		if err := g.Close(); err != nil {
+			t.Fatalf("Close() error = %v, wantErr %v", err, false)
+		}
+
+		if err := g.Close(); err != nil {
+			t.Fatalf("Close() second call error = %v, wantErr %v", err, false)
+		}
+
+		if _, err := NewWithGenerator(g); !errors.Is(err, ErrGeneratorClosed) {
+			t.Fatalf("NewWithGenerator() after Close error = %v, want %v", err, ErrGeneratorClosed)
+		}
+	})
+
+	t.Run("Bit sizes", func(t *testing.T) {
+		g := NewBufferedGenerator(0)
+		defer g.Close()
+
+		for _, n := range []int64{1, 12, 62, 64} {
+			v, err := g.GenerateRandomBits(nil, n)
+			if err != nil {
+				t.Fatalf("GenerateRandomBits(%v) error = %v, wantErr %v", n, err, false)
+			}
+
+			if n < 64 && v >= 1<<uint64(n) {
+				t.Fatalf("GenerateRandomBits(%v) = %v, want less than 2^%v", n, v, n)
+			}
+		}
+
+		for _, n := range []int64{0, -1, 65} {
+			if _, err := g.GenerateRandomBits(nil, n); err == nil {
+				t.Fatalf("GenerateRandomBits(%v) error = %v, wantErr true", n, err)
+			}
+		}
+	})
+
+	t.Run("Reader failing", func(t *testing.T) {
+		g := newBufferedGenerator(&MockRandomReader{}, 4)
+		defer g.Close()
+
+		if _, err := NewWithGenerator(g); err == nil || errors.Is(err, ErrGeneratorClosed) {
+			t.Fatalf("NewWithGenerator() error = %v, want reader error", err)
+		}
+	})
+}
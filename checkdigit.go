@@ -0,0 +1,68 @@
+package id
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WithCheckDigit returns the LDID as returned by Base32 followed by a check character, 27
+// characters in total, for codes that humans read out or type. ParseWithCheckDigit detects
+// every single mistyped character and most swaps of adjacent characters.
+//
+// The check character is computed with the Luhn mod N algorithm over the base32 alphabet
+// (N = 32): starting from the rightmost character, the values of every other character are
+// doubled, the base 32 digits of each result are summed, and the check character is the value
+// that brings the total to a multiple of 32. It returns an empty string for a nil LDID.
+func (id *LDID) WithCheckDigit() string {
+	s := id.Base32()
+	if s == "" {
+		return ""
+	}
+
+	return s + string(base32Alphabet[luhnMod32(s, 2)])
+}
+
+// ParseWithCheckDigit parses a code as returned by WithCheckDigit into a new LDID, in either
+// letter case. It returns an error if the check character does not match, which indicates a
+// transcription error.
+func ParseWithCheckDigit(s string) (*LDID, error) {
+	if len(s) != Base32Length+1 {
+		return &LDID{}, fmt.Errorf("failed to parse LDID: invalid length %d, want %d", len(s), Base32Length+1)
+	}
+
+	s = strings.ToLower(s)
+
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(base32Alphabet, s[i]) < 0 {
+			return &LDID{}, fmt.Errorf("failed to parse LDID: invalid character %q at position %d", s[i], i)
+		}
+	}
+
+	if luhnMod32(s, 1) != 0 {
+		return &LDID{}, fmt.Errorf("failed to parse LDID: check digit mismatch in %q", s)
+	}
+
+	b, err := base32Encoding.DecodeString(s[:Base32Length])
+	if err != nil {
+		return &LDID{}, fmt.Errorf("failed to parse LDID: %w", err)
+	}
+
+	return fromBytes(b), nil
+}
+
+// luhnMod32 computes the Luhn mod 32 sum of s, which must consist of base32Alphabet characters,
+// with the rightmost character weighted by factor. With factor 2 it returns the check value to
+// append; with factor 1 it returns 0 for a string ending in a valid check character.
+func luhnMod32(s string, factor int) int {
+	const n = len(base32Alphabet)
+
+	sum := 0
+	for i := len(s) - 1; i >= 0; i-- {
+		addend := factor * strings.IndexByte(base32Alphabet, s[i])
+		sum += addend/n + addend%n
+
+		factor = 3 - factor
+	}
+
+	return (n - sum%n) % n
+}
@@ -0,0 +1,85 @@
+package id
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWithCheckDigit(t *testing.T) {
+	t.Run("Round trip", func(t *testing.T) {
+		ids, _ := NewBatch(100)
+
+		for _, ldid := range ids {
+			code := ldid.WithCheckDigit()
+
+			if len(code) != Base32Length+1 || code[:Base32Length] != ldid.Base32() {
+				t.Fatalf("WithCheckDigit() = %v, want %v followed by a check character", code, ldid.Base32())
+			}
+
+			parsed, err := ParseWithCheckDigit(code)
+			if err != nil {
+				t.Fatalf("ParseWithCheckDigit() error = %v, wantErr %v", err, false)
+			}
+
+			if parsed.String() != ldid.String() {
+				t.Fatalf("ParseWithCheckDigit() = %v, want %v", parsed, ldid)
+			}
+
+			if _, err := ParseWithCheckDigit(strings.ToUpper(code)); err != nil {
+				t.Fatalf("ParseWithCheckDigit() error = %v, wantErr %v for uppercase", err, false)
+			}
+		}
+	})
+
+	t.Run("Known code", func(t *testing.T) {
+		ldid, _ := FromString("018cc820-d888-7abc-8000-00000000002a")
+
+		if code := ldid.WithCheckDigit(); code != "066cg86oh1tbp0000000000058b" {
+			t.Fatalf("WithCheckDigit() = %v, want %v", code, "066cg86oh1tbp0000000000058b")
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if code := (*LDID)(nil).WithCheckDigit(); code != "" {
+			t.Fatalf("WithCheckDigit() = %v, want empty string", code)
+		}
+	})
+}
+
+func TestParseWithCheckDigit(t *testing.T) {
+	ldid, _ := New()
+	code := ldid.WithCheckDigit()
+
+	t.Run("Single character typos", func(t *testing.T) {
+		for i := 0; i < len(code); i++ {
+			for _, c := range []byte(base32Alphabet) {
+				if c == code[i] {
+					continue
+				}
+
+				typo := code[:i] + string(c) + code[i+1:]
+				if _, err := ParseWithCheckDigit(typo); err == nil {
+					t.Fatalf("ParseWithCheckDigit(%v) error = %v, wantErr %v", typo, err, true)
+				}
+			}
+		}
+	})
+
+	tests := []struct {
+		name string
+		s    string
+	}{
+		{"Too short", code[:Base32Length]},
+		{"Too long", code + "0"},
+		{"Invalid character", "w" + code[1:]},
+		{"Empty", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseWithCheckDigit(tt.s); err == nil {
+				t.Fatalf("ParseWithCheckDigit() error = %v, wantErr %v", err, true)
+			}
+		})
+	}
+}
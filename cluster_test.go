@@ -0,0 +1,107 @@
+package id
+
+import (
+	"crypto/rand"
+	"sync"
+	"testing"
+)
+
+func TestNewClusterGenerator(t *testing.T) {
+	t.Run("Valid node ID", func(t *testing.T) {
+		g, err := NewClusterGenerator(MaxNodeID)
+		if err != nil {
+			t.Fatalf("NewClusterGenerator() error = %v, wantErr %v", err, false)
+		}
+
+		if g.NodeID() != MaxNodeID {
+			t.Fatalf("NodeID() = %v, want %v", g.NodeID(), MaxNodeID)
+		}
+	})
+
+	t.Run("Node ID out of range", func(t *testing.T) {
+		if _, err := NewClusterGenerator(MaxNodeID + 1); err == nil {
+			t.Fatalf("NewClusterGenerator() error = %v, wantErr true", err)
+		}
+	})
+}
+
+func TestClusterGenerator(t *testing.T) {
+	t.Run("Two nodes produce disjoint IDs", func(t *testing.T) {
+		a, _ := NewClusterGenerator(1)
+		b, _ := NewClusterGenerator(2)
+
+		seen := make(map[string]uint16)
+		for _, g := range []*ClusterGenerator{a, b} {
+			for i := 0; i < 1000; i++ {
+				ldid, err := NewWithGenerator(g)
+				if err != nil {
+					t.Fatalf("NewWithGenerator() error = %v, wantErr %v", err, false)
+				}
+
+				if node, ok := seen[ldid.String()]; ok {
+					t.Fatalf("NewWithGenerator() = %v from node %d, already produced by node %d", ldid, g.NodeID(), node)
+				}
+				seen[ldid.String()] = g.NodeID()
+
+				if randA, _ := ldid.RandA(); randA != uint64(g.NodeID()) {
+					t.Fatalf("RandA() = %v, want %v", randA, g.NodeID())
+				}
+			}
+		}
+	})
+
+	t.Run("Concurrent use", func(t *testing.T) {
+		g, _ := NewClusterGenerator(1)
+
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		seen := make(map[string]bool)
+
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				for j := 0; j < 500; j++ {
+					ldid, err := NewWithGenerator(g)
+					if err != nil {
+						t.Errorf("NewWithGenerator() error = %v, wantErr %v", err, false)
+						return
+					}
+
+					mu.Lock()
+					if seen[ldid.String()] {
+						t.Errorf("NewWithGenerator() produced duplicate %v", ldid)
+					}
+					seen[ldid.String()] = true
+					mu.Unlock()
+				}
+			}()
+		}
+
+		wg.Wait()
+	})
+
+	t.Run("Batch", func(t *testing.T) {
+		g, _ := NewClusterGenerator(3)
+
+		ids, err := NewBatchWithGenerator(g, 3)
+		if err != nil {
+			t.Fatalf("NewBatchWithGenerator() error = %v, wantErr %v", err, false)
+		}
+
+		for i, ldid := range ids {
+			if randB, _ := ldid.RandB(); randB != uint64(i) {
+				t.Fatalf("RandB() = %v, want %v", randB, i)
+			}
+		}
+	})
+
+	t.Run("Unsupported field size", func(t *testing.T) {
+		g, _ := NewClusterGenerator(1)
+
+		if _, err := g.GenerateRandomBits(rand.Reader, 8); err == nil {
+			t.Fatalf("GenerateRandomBits() error = %v, wantErr true", err)
+		}
+	})
+}
@@ -0,0 +1,215 @@
+package id
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"fmt"
+	"math/bits"
+)
+
+// Compare compares the bytes of two LDIDs, returning -1 if a sorts before b, 0 if they are
+// equal and 1 if a sorts after b.
+func Compare(a, b *LDID) (int, error) {
+	if a.isNil() || b.isNil() {
+		return 0, fmt.Errorf("failed to compare: %w", ErrNilLDID)
+	}
+
+	return bytes.Compare(a.bf.Bytes(), b.bf.Bytes()), nil
+}
+
+// CompareBytes compares the bytes of the LDID with a 16 byte boundary like Compare, without
+// creating an LDID for the boundary, e.g. for range scans over raw keys in a key-value store.
+// It returns an error if b is not 16 bytes long.
+func (id *LDID) CompareBytes(b []byte) (int, error) {
+	if id.isNil() {
+		return 0, fmt.Errorf("failed to compare: %w", ErrNilLDID)
+	}
+
+	if len(b) != ByteLength {
+		return 0, fmt.Errorf("failed to compare: got %d bytes, want %d", len(b), ByteLength)
+	}
+
+	return bytes.Compare(id.bf.Bytes(), b), nil
+}
+
+// Merge merges two slices that are each sorted by Compare into a new sorted slice, like the
+// merge step of merge sort, e.g. to interleave logs from two sources. LDIDs from a come before
+// equal LDIDs from b. It returns an error if a comparison fails, such as for a nil LDID; LDIDs
+// left over once either slice is exhausted are appended without comparison.
+func Merge(a, b []*LDID) ([]*LDID, error) {
+	merged := make([]*LDID, 0, len(a)+len(b))
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		c, err := Compare(a[i], b[j])
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge LDIDs: %w", err)
+		}
+
+		if c <= 0 {
+			merged = append(merged, a[i])
+			i++
+		} else {
+			merged = append(merged, b[j])
+			j++
+		}
+	}
+
+	merged = append(merged, a[i:]...)
+	merged = append(merged, b[j:]...)
+
+	return merged, nil
+}
+
+// CompareStrings parses two canonical strings with Parse and compares them like Compare.
+func CompareStrings(a, b string) (int, error) {
+	ida, err := Parse(a)
+	if err != nil {
+		return 0, err
+	}
+
+	idb, err := Parse(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return Compare(ida, idb)
+}
+
+// EqualStrings parses two canonical strings with Parse and reports whether they represent
+// the same LDID, regardless of letter case.
+func EqualStrings(a, b string) (bool, error) {
+	c, err := CompareStrings(a, b)
+	if err != nil {
+		return false, err
+	}
+
+	return c == 0, nil
+}
+
+// Distance returns the Hamming distance between two LDIDs: the number of differing bits
+// across their 16 bytes.
+func Distance(a, b *LDID) (int, error) {
+	if a.isNil() || b.isNil() {
+		return 0, fmt.Errorf("failed to compute distance: %w", ErrNilLDID)
+	}
+
+	ab, bb := a.bf.Bytes(), b.bf.Bytes()
+
+	d := 0
+	for i := 0; i < ByteLength; i++ {
+		d += bits.OnesCount8(ab[i] ^ bb[i])
+	}
+
+	return d, nil
+}
+
+// IdentityKey returns the non-timestamp portion of the LDID (version, RandA, variant and
+// RandB; bytes 6 through 15) for use as a map key. Two LDIDs that differ only in their
+// timestamp share the same IdentityKey. The key is not the full ID and cannot be converted
+// back into one. A nil LDID returns the zero key.
+func (id *LDID) IdentityKey() [10]byte {
+	var key [10]byte
+
+	if id.isNil() {
+		return key
+	}
+
+	copy(key[:], id.bf.Bytes()[6:16])
+
+	return key
+}
+
+// SameRandom reports whether two LDIDs have the same RandA and RandB, regardless of their
+// timestamp, version and variant. This is the comparison behind RandomBase64.
+func (id *LDID) SameRandom(other *LDID) (bool, error) {
+	if id.isNil() || other.isNil() {
+		return false, fmt.Errorf("failed to compare: %w", ErrNilLDID)
+	}
+
+	a, b := id.bf.Bytes(), other.bf.Bytes()
+
+	// Mask out the version in the high nibble of byte 6 and the variant in the top 2 bits of byte 8
+	return a[6]&0x0f == b[6]&0x0f && a[7] == b[7] && a[8]&0x3f == b[8]&0x3f && bytes.Equal(a[9:], b[9:]), nil
+}
+
+// EqualConstantTime reports whether two LDIDs are equal, taking the same time regardless of
+// where their bytes differ. Use it instead of Compare when an LDID acts as a secret, such as
+// an unguessable URL token, so comparisons don't leak how much of a guess was correct. It
+// returns false if either LDID is nil.
+func (id *LDID) EqualConstantTime(other *LDID) bool {
+	if id.isNil() || other.isNil() {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare(id.bf.Bytes(), other.bf.Bytes()) == 1
+}
+
+// PlausiblyMonotonic reports whether b could have been generated after a by a monotonic
+// generator: its timestamp is later, or equal with a RandA at least as large. RandB is
+// ignored. This is a heuristic for auditing ordering; independently generated LDIDs pass
+// about half the time within the same millisecond.
+func PlausiblyMonotonic(a, b *LDID) (bool, error) {
+	timestampA, err := a.Timestamp()
+	if err != nil {
+		return false, err
+	}
+
+	timestampB, err := b.Timestamp()
+	if err != nil {
+		return false, err
+	}
+
+	if timestampA != timestampB {
+		return timestampB > timestampA, nil
+	}
+
+	randA, err := a.RandA()
+	if err != nil {
+		return false, err
+	}
+
+	randB, err := b.RandA()
+	if err != nil {
+		return false, err
+	}
+
+	return randB >= randA, nil
+}
+
+// EqualMasked reports whether two LDIDs are equal in the bits set in mask and ignores all other
+// bits: a 1 bit in the mask means compare, a 0 bit means don't care. The mask is in the byte
+// order of Bytes, so a mask with the first 8 bytes set compares the timestamp, version and
+// RandA. It returns false if either LDID is nil.
+func EqualMasked(a, b *LDID, mask [16]byte) bool {
+	if a.isNil() || b.isNil() {
+		return false
+	}
+
+	ab, bb := a.bf.Bytes(), b.bf.Bytes()
+
+	for i, m := range mask {
+		if ab[i]&m != bb[i]&m {
+			return false
+		}
+	}
+
+	return true
+}
+
+// LessString reports whether the canonical string a sorts before b, without parsing them. The
+// hex digits of the canonical form appear in byte order with hyphens at fixed positions, so
+// for strings in the same letter case, such as those returned by String, lexical order is the
+// order of the underlying bytes and LessString agrees with Compare.
+//
+// Only the lengths are checked: strings that are not 36 characters long sort after all others,
+// and lexically among themselves, which keeps the order consistent for sorting.
+func LessString(a, b string) bool {
+	canonicalA, canonicalB := len(a) == CanonicalLength, len(b) == CanonicalLength
+
+	if canonicalA != canonicalB {
+		return canonicalA
+	}
+
+	return a < b
+}
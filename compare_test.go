@@ -0,0 +1,401 @@
+package id
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDistance(t *testing.T) {
+	a, _ := FromString("00000000-0000-0000-0000-000000000000")
+
+	tests := []struct {
+		name     string
+		b        string
+		expected int
+	}{
+		{"Equal", "00000000-0000-0000-0000-000000000000", 0},
+		{"Single bit", "00000000-0000-0000-0000-000000000001", 1},
+		{"Spread bits", "80000000-0000-0300-0000-0000000000f0", 7},
+		{"All bits", "ffffffff-ffff-ffff-ffff-ffffffffffff", 128},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, _ := FromString(tt.b)
+
+			d, err := Distance(a, b)
+			if err != nil {
+				t.Fatalf("Distance() error = %v, wantErr %v", err, false)
+			}
+
+			if d != tt.expected {
+				t.Fatalf("Distance() = %v, want %v", d, tt.expected)
+			}
+		})
+	}
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, err := Distance(a, nil); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("Distance() error = %v, want %v", err, ErrNilLDID)
+		}
+
+		if _, err := Distance(nil, a); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("Distance() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestIdentityKey(t *testing.T) {
+	t.Run("Re-stamped ID", func(t *testing.T) {
+		original := newFromFields(1000, 0xabc, 0x123456789)
+		restamped := newFromFields(2000, 0xabc, 0x123456789)
+
+		if original.IdentityKey() != restamped.IdentityKey() {
+			t.Fatalf("IdentityKey() = %x, want %x", restamped.IdentityKey(), original.IdentityKey())
+		}
+	})
+
+	t.Run("Different randomness", func(t *testing.T) {
+		a := newFromFields(1000, 0xabc, 0x123456789)
+		b := newFromFields(1000, 0xabc, 0x987654321)
+
+		if a.IdentityKey() == b.IdentityKey() {
+			t.Fatalf("IdentityKey() = %x for both, want different keys", a.IdentityKey())
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if key := (*LDID)(nil).IdentityKey(); key != [10]byte{} {
+			t.Fatalf("IdentityKey() = %x, want zero key", key)
+		}
+	})
+}
+
+func TestCompare(t *testing.T) {
+	a := newFromFields(1000, 0, 0)
+	b := newFromFields(2000, 0, 0)
+
+	tests := []struct {
+		name     string
+		a, b     *LDID
+		expected int
+	}{
+		{"Less", a, b, -1},
+		{"Equal", a, newFromFields(1000, 0, 0), 0},
+		{"Greater", b, a, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := Compare(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("Compare() error = %v, wantErr %v", err, false)
+			}
+
+			if c != tt.expected {
+				t.Fatalf("Compare() = %v, want %v", c, tt.expected)
+			}
+		})
+	}
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, err := Compare(a, nil); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("Compare() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestCompareBytes(t *testing.T) {
+	ldid, _ := FromString("018cc820-d888-7abc-8000-00000000002a")
+	min, max := make([]byte, 16), bytes.Repeat([]byte{0xff}, 16)
+
+	tests := []struct {
+		name     string
+		b        []byte
+		expected int
+	}{
+		{"Min boundary", min, 1},
+		{"Max boundary", max, -1},
+		{"Equal", ldid.ProtoBytes(), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ldid.CompareBytes(tt.b)
+			if err != nil {
+				t.Fatalf("CompareBytes() error = %v, wantErr %v", err, false)
+			}
+
+			if c != tt.expected {
+				t.Fatalf("CompareBytes() = %v, want %v", c, tt.expected)
+			}
+		})
+	}
+
+	t.Run("Invalid length", func(t *testing.T) {
+		for _, b := range [][]byte{nil, min[:15], append(max, 0)} {
+			if _, err := ldid.CompareBytes(b); err == nil {
+				t.Fatalf("CompareBytes(%x) error = %v, wantErr %v", b, err, true)
+			}
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, err := (*LDID)(nil).CompareBytes(min); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("CompareBytes() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestMerge(t *testing.T) {
+	ids := Corpus(1, 8, time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC), time.Millisecond)
+
+	tests := []struct {
+		name     string
+		a, b     []*LDID
+		expected []*LDID
+	}{
+		{"Interleaved", []*LDID{ids[0], ids[2], ids[3], ids[6]}, []*LDID{ids[1], ids[4], ids[5], ids[7]}, ids},
+		{"Disjoint", ids[4:], ids[:4], ids},
+		{"Empty a", nil, ids[:3], ids[:3]},
+		{"Empty b", ids[:3], nil, ids[:3]},
+		{"Both empty", nil, nil, []*LDID{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, err := Merge(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("Merge() error = %v, wantErr %v", err, false)
+			}
+
+			if len(merged) != len(tt.expected) {
+				t.Fatalf("Merge() length = %v, want %v", len(merged), len(tt.expected))
+			}
+
+			for i := range merged {
+				if merged[i] != tt.expected[i] {
+					t.Fatalf("Merge()[%d] = %v, want %v", i, merged[i], tt.expected[i])
+				}
+			}
+		})
+	}
+
+	t.Run("Equal LDIDs keep order", func(t *testing.T) {
+		dup, _ := FromString(ids[1].String())
+
+		merged, _ := Merge([]*LDID{ids[1]}, []*LDID{dup})
+		if merged[0] != ids[1] || merged[1] != dup {
+			t.Fatalf("Merge() = %v, want LDID from a first", merged)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, err := Merge([]*LDID{nil}, ids[:1]); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("Merge() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestCompareStrings(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected int
+		wantErr  bool
+	}{
+		{"Equal", "018cc251-f400-7abc-8def-0123456789ab", "018cc251-f400-7abc-8def-0123456789ab", 0, false},
+		{"Equal ignoring case", "018cc251-f400-7abc-8def-0123456789ab", "018CC251-F400-7ABC-8DEF-0123456789AB", 0, false},
+		{"Less", "018cc251-f400-7abc-8def-0123456789ab", "018cc251-f401-7abc-8def-0123456789ab", -1, false},
+		{"Greater", "018cc251-f401-7abc-8def-0123456789ab", "018cc251-f400-7abc-8def-0123456789ab", 1, false},
+		{"Malformed a", "not-an-id", "018cc251-f400-7abc-8def-0123456789ab", 0, true},
+		{"Malformed b", "018cc251-f400-7abc-8def-0123456789ab", "018cc251f4007abc8def0123456789ab", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := CompareStrings(tt.a, tt.b)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CompareStrings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if c != tt.expected {
+				t.Fatalf("CompareStrings() = %v, want %v", c, tt.expected)
+			}
+
+			equal, err := EqualStrings(tt.a, tt.b)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EqualStrings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if expectedEqual := !tt.wantErr && tt.expected == 0; equal != expectedEqual {
+				t.Fatalf("EqualStrings() = %v, want %v", equal, expectedEqual)
+			}
+		})
+	}
+}
+
+func TestEqualConstantTime(t *testing.T) {
+	t.Run("Matches Compare", func(t *testing.T) {
+		ids, err := NewBatch(20)
+		if err != nil {
+			t.Fatalf("NewBatch() error = %v, wantErr %v", err, false)
+		}
+
+		for _, a := range ids {
+			for _, b := range ids {
+				c, _ := Compare(a, b)
+
+				if equal := a.EqualConstantTime(b); equal != (c == 0) {
+					t.Fatalf("EqualConstantTime(%v, %v) = %v, want %v", a, b, equal, c == 0)
+				}
+			}
+		}
+	})
+
+	t.Run("Equal copies", func(t *testing.T) {
+		a, _ := New()
+		b, _ := FromString(a.String())
+
+		if !a.EqualConstantTime(b) {
+			t.Fatalf("EqualConstantTime() = %v, want %v", false, true)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		a, _ := New()
+
+		if a.EqualConstantTime(nil) || (*LDID)(nil).EqualConstantTime(a) {
+			t.Fatalf("EqualConstantTime() with nil LDID = %v, want %v", true, false)
+		}
+	})
+}
+
+func TestPlausiblyMonotonic(t *testing.T) {
+	ts := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		a, b     *LDID
+		expected bool
+	}{
+		{"Later timestamp", TestID(ts, 5), TestID(ts.Add(time.Millisecond), 1), true},
+		{"Same timestamp, higher RandA", TestID(ts, 1), TestID(ts, 2), true},
+		{"Same timestamp and RandA", TestID(ts, 1), TestID(ts, 1), true},
+		{"Same timestamp, lower RandA", TestID(ts, 2), TestID(ts, 1), false},
+		{"Earlier timestamp", TestID(ts.Add(time.Millisecond), 1), TestID(ts, 5), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PlausiblyMonotonic(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("PlausiblyMonotonic() error = %v, wantErr %v", err, false)
+			}
+
+			if got != tt.expected {
+				t.Fatalf("PlausiblyMonotonic() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, err := PlausiblyMonotonic(TestID(ts, 0), nil); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("PlausiblyMonotonic() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestEqualMasked(t *testing.T) {
+	// Compare the timestamp and version, ignoring RandA, the variant and RandB
+	mask := [16]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xf0}
+
+	t.Run("Same time, different random data", func(t *testing.T) {
+		g := &DefaultGenerator{
+			Clock: func() time.Time {
+				return time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+			},
+		}
+
+		a, _ := NewWithGenerator(g)
+		b, _ := NewWithGenerator(g)
+
+		if a.String() == b.String() {
+			t.Fatalf("NewWithGenerator() = %v twice, want different random data", a)
+		}
+
+		if !EqualMasked(a, b, mask) {
+			t.Fatalf("EqualMasked(%v, %v) = %v, want %v", a, b, false, true)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		a, b     string
+		mask     [16]byte
+		expected bool
+	}{
+		{"Different time", "018cc251-f400-7abc-8def-0123456789ab", "018cc251-f401-7abc-8def-0123456789ab", mask, false},
+		{"Different version", "018cc251-f400-7abc-8def-0123456789ab", "018cc251-f400-4abc-8def-0123456789ab", mask, false},
+		{"Single bit", "018cc251-f400-7abc-8def-0123456789ab", "018cc251-f400-7abc-8def-0123456789aa", [16]byte{15: 0x01}, false},
+		{"Empty mask", "018cc251-f400-7abc-8def-0123456789ab", "ffffffff-ffff-ffff-ffff-ffffffffffff", [16]byte{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, _ := Parse(tt.a)
+			b, _ := Parse(tt.b)
+
+			if equal := EqualMasked(a, b, tt.mask); equal != tt.expected {
+				t.Fatalf("EqualMasked() = %v, want %v", equal, tt.expected)
+			}
+		})
+	}
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		ldid, _ := New()
+
+		if EqualMasked(ldid, nil, mask) {
+			t.Fatalf("EqualMasked() = %v, want %v", true, false)
+		}
+	})
+}
+
+func TestLessString(t *testing.T) {
+	t.Run("Agrees with Compare", func(t *testing.T) {
+		ids, _ := NewBatch(200)
+		ids = append(ids, Corpus(1, 50, time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC), 0)...)
+
+		for i := 0; i < len(ids); i++ {
+			for j := 0; j < len(ids); j += 7 {
+				c, _ := Compare(ids[i], ids[j])
+
+				if less := LessString(ids[i].String(), ids[j].String()); less != (c < 0) {
+					t.Fatalf("LessString(%v, %v) = %v, want %v", ids[i], ids[j], less, c < 0)
+				}
+
+				if less := LessString(ids[i].StringUpper(), ids[j].StringUpper()); less != (c < 0) {
+					t.Fatalf("LessString(%v, %v) uppercase = %v, want %v", ids[i], ids[j], less, c < 0)
+				}
+			}
+		}
+	})
+
+	t.Run("Non-canonical strings sort last", func(t *testing.T) {
+		max := "ffffffff-ffff-ffff-ffff-ffffffffffff"
+
+		if !LessString(max, "0") {
+			t.Fatalf("LessString(%v, %v) = %v, want %v", max, "0", false, true)
+		}
+
+		if LessString("0", max) {
+			t.Fatalf("LessString(%v, %v) = %v, want %v", "0", max, true, false)
+		}
+
+		if !LessString("0", "1") {
+			t.Fatalf("LessString(%v, %v) = %v, want %v", "0", "1", false, true)
+		}
+	})
+}
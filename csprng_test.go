@@ -0,0 +1,131 @@
+package id
+
+import (
+	"crypto/rand"
+	"io"
+	"testing"
+	"time"
+)
+
+// Mocks
+
+type countingReader struct {
+	r     io.Reader
+	n     int
+	calls int
+}
+
+func (c *countingReader) Read(b []byte) (int, error) {
+	n, err := c.r.Read(b)
+	c.n += n
+	c.calls++
+	return n, err
+}
+
+// Test functions
+
+func TestCSPRNGGenerator(t *testing.T) {
+	t.Run("Valid LDIDs", func(t *testing.T) {
+		g := NewCSPRNGGenerator(1<<20, time.Hour)
+
+		seen := make(map[string]bool)
+		for i := 0; i < 100; i++ {
+			ldid, err := NewWithGenerator(g)
+			if err != nil {
+				t.Fatalf("NewWithGenerator() error = %v, wantErr %v", err, false)
+			}
+
+			if err := ldid.validate(); err != nil {
+				t.Fatalf("NewWithGenerator() = %v, want valid LDID: %v", ldid, err)
+			}
+
+			if seen[ldid.String()] {
+				t.Fatalf("NewWithGenerator() produced duplicate %v", ldid)
+			}
+			seen[ldid.String()] = true
+		}
+	})
+
+	t.Run("Reseed by volume", func(t *testing.T) {
+		g := NewCSPRNGGenerator(16, 0)
+		r := &countingReader{r: rand.Reader}
+
+		for i := 0; i < 4; i++ {
+			if _, err := g.GenerateRandomBits(r, 64); err != nil {
+				t.Fatalf("GenerateRandomBits() error = %v, wantErr %v", err, false)
+			}
+		}
+
+		// 8 bytes per call: seeded on the 1st call and reseeded on the 3rd
+		if g.seeds != 2 {
+			t.Fatalf("seeds = %v, want %v", g.seeds, 2)
+		}
+
+		if r.n != 2*48 {
+			t.Fatalf("seed bytes read = %v, want %v", r.n, 2*48)
+		}
+	})
+
+	t.Run("Reseed by time", func(t *testing.T) {
+		g := NewCSPRNGGenerator(0, time.Hour)
+
+		if _, err := g.GenerateRandomBits(rand.Reader, 64); err != nil {
+			t.Fatalf("GenerateRandomBits() error = %v, wantErr %v", err, false)
+		}
+
+		g.seededAt = g.seededAt.Add(-2 * time.Hour)
+
+		if _, err := g.GenerateRandomBits(rand.Reader, 64); err != nil {
+			t.Fatalf("GenerateRandomBits() error = %v, wantErr %v", err, false)
+		}
+
+		if g.seeds != 2 {
+			t.Fatalf("seeds = %v, want %v", g.seeds, 2)
+		}
+	})
+
+	t.Run("Seed failing", func(t *testing.T) {
+		g := NewCSPRNGGenerator(0, 0)
+
+		if _, err := g.GenerateRandomBits(&MockRandomReader{}, 64); err == nil {
+			t.Fatalf("GenerateRandomBits() error = %v, wantErr true", err)
+		}
+	})
+
+	t.Run("Seed from failing Reader", func(t *testing.T) {
+		g := NewCSPRNGGenerator(0, 0)
+		g.Reader = &MockRandomReader{}
+
+		if _, err := NewWithGenerator(g); err == nil {
+			t.Fatalf("NewWithGenerator() error = %v, wantErr true", err)
+		}
+	})
+
+	t.Run("Invalid n", func(t *testing.T) {
+		g := NewCSPRNGGenerator(0, 0)
+
+		for _, n := range []int64{0, 65} {
+			if _, err := g.GenerateRandomBits(rand.Reader, n); err == nil {
+				t.Fatalf("GenerateRandomBits(%d) error = %v, wantErr true", n, err)
+			}
+		}
+	})
+}
+
+func BenchmarkNewWithGenerator(b *testing.B) {
+	generators := map[string]Generator{
+		"DefaultGenerator": defaultGenerator,
+		"CSPRNGGenerator":  NewCSPRNGGenerator(1<<20, time.Hour),
+	}
+
+	for name, g := range generators {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := NewWithGenerator(g); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package id
+
+import (
+	"crypto/rand"
+)
+
+// Child creates a new LDID with the same timestamp as the parent LDID and fresh random data
+// from the provided generator, for correlating related events.
+//
+// The parent and child are not linked cryptographically: nothing in the child identifies its
+// parent, so the relationship must be stored alongside the IDs (e.g. a parent_id column). Two
+// LDIDs sharing a timestamp is only a hint, as unrelated IDs can share a millisecond too.
+func (id *LDID) Child(g Generator) (*LDID, error) {
+	timestamp, err := id.Timestamp()
+	if err != nil {
+		return &LDID{}, err
+	}
+
+	randA, err := g.GenerateRandomBits(rand.Reader, int64(randASize))
+	if err != nil {
+		return &LDID{}, err
+	}
+
+	randB, err := g.GenerateRandomBits(rand.Reader, int64(randBSize))
+	if err != nil {
+		return &LDID{}, err
+	}
+
+	child := newFromFields(timestamp, randA, saltRandB(g, randB))
+
+	if err := child.bf.Error(); err != nil {
+		return &LDID{}, err
+	}
+
+	return child, nil
+}
+
+// Anonymize returns a copy of the LDID with the timestamp replaced by random bits from the
+// provided generator and the version set to 4, so the result is a well-formed random UUID
+// of the same size that no longer reveals when the original was created.
+//
+// The time ordering of LDIDs is lost: anonymized LDIDs sort randomly, and Timestamp and Time
+// return meaningless values for them.
+func (id *LDID) Anonymize(g Generator) (*LDID, error) {
+	if id.isNil() {
+		return &LDID{}, ErrNilLDID
+	}
+
+	random, err := g.GenerateRandomBits(rand.Reader, int64(timestampSize))
+	if err != nil {
+		return &LDID{}, err
+	}
+
+	anonymized := fromBytes(id.bf.Bytes())
+	anonymized.bf.InsertUint64(timestampOffset, timestampSize, random)
+	anonymized.bf.InsertUint64(versionOffset, versionSize, 0b0100)
+
+	if err := anonymized.bf.Error(); err != nil {
+		return &LDID{}, err
+	}
+
+	return anonymized, nil
+}
+
+// AsV4 returns a copy of the LDID with the version set to 4, for systems that only accept
+// random UUIDs. All other bits are kept, so the timestamp is still present but is read as
+// random data: v4 consumers must not rely on the result sorting by creation time, and the
+// creation time remains recoverable from the first 6 bytes. Use Anonymize to remove it. It
+// returns nil for a nil LDID.
+func (id *LDID) AsV4() *LDID {
+	if id.isNil() {
+		return nil
+	}
+
+	v4 := fromBytes(id.bf.Bytes())
+	v4.bf.InsertUint64(versionOffset, versionSize, 0b0100)
+
+	return v4
+}
@@ -0,0 +1,145 @@
+package id
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestChild(t *testing.T) {
+	t.Run("Same timestamp", func(t *testing.T) {
+		parent, err := New()
+		if err != nil {
+			t.Fatalf("New() error = %v, wantErr %v", err, false)
+		}
+
+		child, err := parent.Child(defaultGenerator)
+		if err != nil {
+			t.Fatalf("Child() error = %v, wantErr %v", err, false)
+		}
+
+		parentTimestamp, _ := parent.Timestamp()
+		if childTimestamp, _ := child.Timestamp(); childTimestamp != parentTimestamp {
+			t.Fatalf("child.Timestamp() = %v, want %v", childTimestamp, parentTimestamp)
+		}
+
+		if child.String() == parent.String() {
+			t.Fatalf("Child() = %v, want different from parent", child)
+		}
+
+		if err := child.validate(); err != nil {
+			t.Fatalf("Child() = %v, want valid LDID: %v", child, err)
+		}
+	})
+
+	t.Run("GenerateRandomBits failing", func(t *testing.T) {
+		parent, _ := New()
+		m := &MockGenerator{
+			GenerateRandomBitsFunc: func(randReader io.Reader, n int64) (uint64, error) {
+				return 0, errors.New("mock error")
+			},
+		}
+
+		if _, err := parent.Child(m); err == nil {
+			t.Fatalf("Child() error = %v, wantErr true", err)
+		}
+	})
+
+	t.Run("Nil parent", func(t *testing.T) {
+		if _, err := (*LDID)(nil).Child(defaultGenerator); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("Child() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestAnonymize(t *testing.T) {
+	t.Run("Version 4 with random timestamp", func(t *testing.T) {
+		ldid, _ := New()
+
+		anonymized, err := ldid.Anonymize(defaultGenerator)
+		if err != nil {
+			t.Fatalf("Anonymize() error = %v, wantErr %v", err, false)
+		}
+
+		if version, _ := anonymized.Version(); version != 4 {
+			t.Fatalf("Anonymize() version = %v, want %v", version, 4)
+		}
+
+		if variant, _ := anonymized.Variant(); variant != 0b10 {
+			t.Fatalf("Anonymize() variant = %v, want %v", variant, 0b10)
+		}
+
+		if bytes.Equal(anonymized.Bytes()[:6], ldid.Bytes()[:6]) {
+			t.Fatalf("Anonymize() timestamp bytes = %x, want different from %x", anonymized.Bytes()[:6], ldid.Bytes()[:6])
+		}
+
+		if !bytes.Equal(anonymized.Bytes()[8:], ldid.Bytes()[8:]) {
+			t.Fatalf("Anonymize() rand_b bytes = %x, want %x", anonymized.Bytes()[8:], ldid.Bytes()[8:])
+		}
+
+		if version, _ := ldid.Version(); version != 7 {
+			t.Fatalf("Anonymize() modified original version = %v, want %v", version, 7)
+		}
+	})
+
+	t.Run("GenerateRandomBits failing", func(t *testing.T) {
+		ldid, _ := New()
+		m := &MockGenerator{
+			GenerateRandomBitsFunc: func(randReader io.Reader, n int64) (uint64, error) {
+				return 0, errors.New("mock error")
+			},
+		}
+
+		if _, err := ldid.Anonymize(m); err == nil {
+			t.Fatalf("Anonymize() error = %v, wantErr true", err)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, err := (*LDID)(nil).Anonymize(defaultGenerator); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("Anonymize() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestAsV4(t *testing.T) {
+	t.Run("Relabels version", func(t *testing.T) {
+		ldid, _ := New()
+
+		v4 := ldid.AsV4()
+
+		if version, _ := v4.Version(); version != 4 {
+			t.Fatalf("AsV4() version = %v, want %v", version, 4)
+		}
+
+		if variant, _ := v4.Variant(); variant != 0b10 {
+			t.Fatalf("AsV4() variant = %v, want %v", variant, 0b10)
+		}
+
+		original, relabeled := ldid.Bytes(), v4.Bytes()
+		for i := range original {
+			if i == 6 {
+				// Only the version nibble in byte 6 changes
+				if original[i]&0x0f != relabeled[i]&0x0f {
+					t.Fatalf("AsV4() byte %d = %#x, want low nibble of %#x", i, relabeled[i], original[i])
+				}
+				continue
+			}
+
+			if original[i] != relabeled[i] {
+				t.Fatalf("AsV4() byte %d = %#x, want %#x", i, relabeled[i], original[i])
+			}
+		}
+
+		if version, _ := ldid.Version(); version != 7 {
+			t.Fatalf("AsV4() modified original version = %v, want %v", version, 7)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if v4 := (*LDID)(nil).AsV4(); v4 != nil {
+			t.Fatalf("AsV4() = %v, want nil", v4)
+		}
+	})
+}
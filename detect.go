@@ -0,0 +1,136 @@
+package id
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+	"sync"
+)
+
+// DuplicateDetector detects repeated LDIDs in a stream using a fixed amount of memory, backed
+// by a Bloom filter.
+//
+// Add never misses an LDID it has seen before, but may report an unseen LDID as seen with
+// roughly the false positive rate the detector was sized for. The rate rises when more than the
+// expected number of LDIDs is added. A DuplicateDetector is safe for concurrent use.
+type DuplicateDetector struct {
+	mu     sync.Mutex
+	bits   []uint64
+	m      uint64
+	hashes uint64
+}
+
+// NewDuplicateDetector creates a new DuplicateDetector sized for expectedN LDIDs at the given
+// false positive rate. An expectedN below 1 is treated as 1, and a rate outside (0, 1) as 0.01.
+// Memory use is about 1.44·log2(1/rate) bits per expected LDID, e.g. 1.2 MB for a million LDIDs
+// at a rate of 0.01.
+func NewDuplicateDetector(expectedN int, falsePositiveRate float64) *DuplicateDetector {
+	if expectedN < 1 {
+		expectedN = 1
+	}
+
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		falsePositiveRate = 0.01
+	}
+
+	n := float64(expectedN)
+	m := math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	k := math.Max(1, math.Round(m/n*math.Ln2))
+
+	return &DuplicateDetector{
+		bits:   make([]uint64, (uint64(m)+63)/64),
+		m:      uint64(m),
+		hashes: uint64(k),
+	}
+}
+
+// Add records the LDID and reports whether it was probably added before. A nil LDID is ignored
+// and reported as not seen.
+func (d *DuplicateDetector) Add(id *LDID) bool {
+	if id.isNil() {
+		return false
+	}
+
+	h := fnv.New128a()
+	h.Write(id.bf.Bytes())
+	sum := h.Sum(nil)
+
+	// Derive the bit positions with double hashing from the two halves of the 128-bit hash
+	h1 := binary.BigEndian.Uint64(sum[0:8])
+	h2 := binary.BigEndian.Uint64(sum[8:16]) | 1
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	seen := true
+	for i := uint64(0); i < d.hashes; i++ {
+		pos := (h1 + i*h2) % d.m
+		word, mask := pos/64, uint64(1)<<(pos%64)
+
+		if d.bits[word]&mask == 0 {
+			seen = false
+			d.bits[word] |= mask
+		}
+	}
+
+	return seen
+}
+
+// SlidingWindowDetector detects LDIDs repeated within the last N LDIDs added, using a ring
+// buffer. Unlike DuplicateDetector it is exact, but forgets LDIDs once they leave the window,
+// which makes it suited to catching immediate duplicates such as retried writes. A
+// SlidingWindowDetector is safe for concurrent use.
+type SlidingWindowDetector struct {
+	mu     sync.Mutex
+	ring   [][ByteLength]byte
+	counts map[[ByteLength]byte]int
+	next   int
+	full   bool
+}
+
+// NewSlidingWindowDetector creates a new SlidingWindowDetector that remembers the last size
+// LDIDs. A size below 1 is treated as 1.
+func NewSlidingWindowDetector(size int) *SlidingWindowDetector {
+	if size < 1 {
+		size = 1
+	}
+
+	return &SlidingWindowDetector{
+		ring:   make([][ByteLength]byte, size),
+		counts: make(map[[ByteLength]byte]int, size),
+	}
+}
+
+// Add reports whether the LDID is among the last size LDIDs added, then records it, evicting
+// the oldest LDID once the window is full. A nil LDID is ignored and reported as not seen.
+func (d *SlidingWindowDetector) Add(id *LDID) bool {
+	if id.isNil() {
+		return false
+	}
+
+	var key [ByteLength]byte
+	copy(key[:], id.bf.Bytes())
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	seen := d.counts[key] > 0
+
+	if d.full {
+		evicted := d.ring[d.next]
+		if d.counts[evicted]--; d.counts[evicted] == 0 {
+			delete(d.counts, evicted)
+		}
+	}
+
+	d.ring[d.next] = key
+	d.counts[key]++
+
+	d.next++
+	if d.next == len(d.ring) {
+		d.next = 0
+		d.full = true
+	}
+
+	return seen
+}
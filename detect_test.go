@@ -0,0 +1,116 @@
+package id
+
+import (
+	"testing"
+)
+
+func TestDuplicateDetector(t *testing.T) {
+	t.Run("Injected duplicates", func(t *testing.T) {
+		d := NewDuplicateDetector(10000, 0.001)
+
+		ids, err := NewBatch(5000)
+		if err != nil {
+			t.Fatalf("NewBatch() error = %v, wantErr %v", err, false)
+		}
+
+		falsePositives := 0
+		for _, ldid := range ids {
+			if d.Add(ldid) {
+				falsePositives++
+			}
+		}
+
+		// Expect about 5 false positives at this rate; allow generous slack
+		if falsePositives > 50 {
+			t.Fatalf("Add() false positives = %v, want at most %v", falsePositives, 50)
+		}
+
+		for _, i := range []int{0, 1234, 4999} {
+			duplicate, _ := FromString(ids[i].String())
+
+			if !d.Add(duplicate) {
+				t.Fatalf("Add(%v) = %v, want %v for duplicate", duplicate, false, true)
+			}
+		}
+	})
+
+	t.Run("Invalid parameters", func(t *testing.T) {
+		d := NewDuplicateDetector(0, 2)
+		ldid, _ := New()
+
+		if d.Add(ldid) {
+			t.Fatalf("Add() = %v, want %v for first occurrence", true, false)
+		}
+
+		if !d.Add(ldid) {
+			t.Fatalf("Add() = %v, want %v for duplicate", false, true)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		d := NewDuplicateDetector(10, 0.01)
+
+		if d.Add(nil) || d.Add(nil) {
+			t.Fatalf("Add(nil) = %v, want %v", true, false)
+		}
+	})
+}
+
+func TestSlidingWindowDetector(t *testing.T) {
+	ids, err := NewBatch(10)
+	if err != nil {
+		t.Fatalf("NewBatch() error = %v, wantErr %v", err, false)
+	}
+
+	t.Run("Duplicate inside and outside window", func(t *testing.T) {
+		d := NewSlidingWindowDetector(3)
+
+		for _, ldid := range ids[:4] {
+			if d.Add(ldid) {
+				t.Fatalf("Add(%v) = %v, want %v", ldid, true, false)
+			}
+		}
+
+		// The window now holds ids[1:4]
+		inside, _ := FromString(ids[2].String())
+		if !d.Add(inside) {
+			t.Fatalf("Add(%v) = %v, want %v for duplicate inside window", inside, false, true)
+		}
+
+		// The window now holds ids[2], ids[3] and ids[2] again, so ids[1] has been evicted
+		outside, _ := FromString(ids[1].String())
+		if d.Add(outside) {
+			t.Fatalf("Add(%v) = %v, want %v for duplicate outside window", outside, true, false)
+		}
+
+		// ids[2] is still in the window once
+		if !d.Add(ids[2]) {
+			t.Fatalf("Add(%v) = %v, want %v for duplicate inside window", ids[2], false, true)
+		}
+	})
+
+	t.Run("Invalid size", func(t *testing.T) {
+		d := NewSlidingWindowDetector(0)
+
+		if d.Add(ids[0]) {
+			t.Fatalf("Add(%v) = %v, want %v", ids[0], true, false)
+		}
+
+		if !d.Add(ids[0]) {
+			t.Fatalf("Add(%v) = %v, want %v", ids[0], false, true)
+		}
+
+		d.Add(ids[1])
+		if d.Add(ids[0]) {
+			t.Fatalf("Add(%v) = %v, want %v", ids[0], true, false)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		d := NewSlidingWindowDetector(3)
+
+		if d.Add(nil) || d.Add(nil) {
+			t.Fatalf("Add(nil) = %v, want %v", true, false)
+		}
+	})
+}
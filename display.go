@@ -0,0 +1,179 @@
+package id
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// StringUpper formats the LDID like String, but with uppercase hex digits, for systems that
+// require uppercase UUIDs. It returns an empty string for a nil LDID.
+func (id *LDID) StringUpper() string {
+	return strings.ToUpper(id.String())
+}
+
+// ShortCode returns an 8 character code derived from the LDID for display and human reference.
+// It is the base32 encoding of the first 40 bits of the SHA-256 hash of the LDID bytes.
+//
+// A short code cannot be converted back into an LDID and is not unique: among n LDIDs the
+// probability of any two sharing a code is roughly n²/2⁴¹, about 1 in 2 million for 1000 IDs
+// and even odds around 1.2 million IDs. Do not use it as a lookup key. It returns an empty string
+// for a nil LDID.
+func (id *LDID) ShortCode() string {
+	if id.isNil() {
+		return ""
+	}
+
+	sum := sha256.Sum256(id.bf.Bytes())
+
+	return base32Encoding.EncodeToString(sum[:5])
+}
+
+// Short returns an abbreviated form of the LDID for dense displays such as table columns: the
+// first 8 and the last 4 hex digits of the canonical string joined by an ellipsis, e.g.
+// 018cc820…002a. The prefix is the high part of the timestamp, so LDIDs created close together
+// mostly differ in the suffix.
+//
+// Short is lossy and cannot be parsed back into an LDID; different LDIDs can share a short
+// form. It returns an empty string for a nil LDID.
+func (id *LDID) Short() string {
+	s := id.String()
+	if s == "" {
+		return ""
+	}
+
+	return s[:8] + "…" + s[CanonicalLength-4:]
+}
+
+// Filename returns a file name for the LDID: the 26 character Base32 encoding, which only uses
+// the digits and lowercase letters a through v, followed by ext. A leading dot is added to ext
+// if it has none; ext is otherwise used as is. The name contains no characters reserved on
+// Windows or Unix, cannot collide on case-insensitive file systems and sorts by creation time.
+// It returns an empty string for a nil LDID.
+func (id *LDID) Filename(ext string) string {
+	if id.isNil() {
+		return ""
+	}
+
+	if ext != "" && !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+
+	return id.Base32() + ext
+}
+
+// Decompose returns the fields of the LDID as a map for debugging and introspection, e.g. to
+// serve as JSON from an admin endpoint. The keys are "timestamp" (Unix milliseconds), "time"
+// (RFC 3339 in UTC), "version", "rand_a", "variant" and "rand_b".
+func (id *LDID) Decompose() (map[string]any, error) {
+	timestamp, err := id.Timestamp()
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := id.Time()
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := id.Version()
+	if err != nil {
+		return nil, err
+	}
+
+	randA, err := id.RandA()
+	if err != nil {
+		return nil, err
+	}
+
+	variant, err := id.Variant()
+	if err != nil {
+		return nil, err
+	}
+
+	randB, err := id.RandB()
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"timestamp": timestamp,
+		"time":      t.Format(time.RFC3339Nano),
+		"version":   version,
+		"rand_a":    randA,
+		"variant":   variant,
+		"rand_b":    randB,
+	}, nil
+}
+
+// BitString returns the 128 bits of the LDID grouped by field, e.g. to diagnose malformed IDs:
+//
+//	timestamp=<48 bits> version=0111 randA=<12 bits> variant=10 randB=<62 bits>
+//
+// The groups follow the RFC 9562 field offsets, regardless of whether the version and variant
+// hold their expected values.
+func (id *LDID) BitString() (string, error) {
+	if id.isNil() {
+		return "", ErrNilLDID
+	}
+
+	var bits strings.Builder
+	for _, b := range id.bf.Bytes() {
+		fmt.Fprintf(&bits, "%08b", b)
+	}
+
+	s := bits.String()
+
+	return fmt.Sprintf("timestamp=%s version=%s randA=%s variant=%s randB=%s",
+		s[timestampOffset:versionOffset],
+		s[versionOffset:randAOffset],
+		s[randAOffset:variantOffset],
+		s[variantOffset:randBOffset],
+		s[randBOffset:],
+	), nil
+}
+
+// LogValue implements slog.LogValuer, so an LDID passed to slog.Any or as a log attribute is
+// logged as its canonical string rather than as a struct. Use Decompose for the individual
+// fields. A nil LDID is logged as an empty string.
+func (id *LDID) LogValue() slog.Value {
+	return slog.StringValue(id.String())
+}
+
+// AgeString returns how long ago the LDID was created as a short human-readable string, e.g.
+// for admin UIs. See AgeStringAt for the format.
+func (id *LDID) AgeString() (string, error) {
+	return id.AgeStringAt(time.Now())
+}
+
+// AgeStringAt returns how long before now the LDID was created as a short human-readable
+// string. Ages are rounded down to whole units:
+//
+//   - under a minute, or in the future: "just now"
+//   - under an hour: "3m ago"
+//   - under a day: "2h ago"
+//   - under two days: "yesterday"
+//   - otherwise: "5d ago"
+func (id *LDID) AgeStringAt(now time.Time) (string, error) {
+	t, err := id.Time()
+	if err != nil {
+		return "", err
+	}
+
+	age := now.Sub(t)
+
+	switch {
+	case age < time.Minute:
+		return "just now", nil
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", age/time.Minute), nil
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", age/time.Hour), nil
+	case age < 48*time.Hour:
+		return "yesterday", nil
+	default:
+		return fmt.Sprintf("%dd ago", age/(24*time.Hour)), nil
+	}
+}
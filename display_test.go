@@ -0,0 +1,287 @@
+package id
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStringUpper(t *testing.T) {
+	t.Run("Uppercase", func(t *testing.T) {
+		ldid, _ := FromString("018cc251-f400-7abc-8def-0123456789ab")
+		expected := "018CC251-F400-7ABC-8DEF-0123456789AB"
+
+		if str := ldid.StringUpper(); str != expected {
+			t.Fatalf("StringUpper() = %v, want %v", str, expected)
+		}
+	})
+
+	t.Run("Round trip", func(t *testing.T) {
+		ldid, _ := New()
+
+		parsed, err := FromString(ldid.StringUpper())
+		if err != nil {
+			t.Fatalf("FromString() error = %v, wantErr %v", err, false)
+		}
+
+		if parsed.String() != ldid.String() {
+			t.Fatalf("FromString() = %v, want %v", parsed, ldid)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if str := (*LDID)(nil).StringUpper(); str != "" {
+			t.Fatalf("StringUpper() = %v, want empty string", str)
+		}
+	})
+}
+
+func TestShortCode(t *testing.T) {
+	t.Run("Deterministic", func(t *testing.T) {
+		a, _ := FromString("018cc251-f400-7abc-8def-0123456789ab")
+		b, _ := FromString("018cc251-f400-7abc-8def-0123456789ab")
+
+		if a.ShortCode() != b.ShortCode() {
+			t.Fatalf("ShortCode() = %v and %v, want equal", a.ShortCode(), b.ShortCode())
+		}
+	})
+
+	t.Run("Format", func(t *testing.T) {
+		ldid, _ := New()
+
+		if match, _ := regexp.MatchString(`^[0-9a-v]{8}$`, ldid.ShortCode()); !match {
+			t.Fatalf("ShortCode() = %v, want 8 base32 characters", ldid.ShortCode())
+		}
+	})
+
+	t.Run("Different IDs", func(t *testing.T) {
+		a, _ := FromString("018cc251-f400-7abc-8def-0123456789ab")
+		b, _ := FromString("018cc251-f400-7abc-8def-0123456789ac")
+
+		if a.ShortCode() == b.ShortCode() {
+			t.Fatalf("ShortCode() = %v for both, want different codes", a.ShortCode())
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if code := (*LDID)(nil).ShortCode(); code != "" {
+			t.Fatalf("ShortCode() = %v, want empty string", code)
+		}
+	})
+}
+
+func TestShort(t *testing.T) {
+	t.Run("Known ID", func(t *testing.T) {
+		ldid, _ := FromString("018cc820-d888-7abc-8000-00000000002a")
+
+		if short := ldid.Short(); short != "018cc820…002a" {
+			t.Fatalf("Short() = %v, want %v", short, "018cc820…002a")
+		}
+	})
+
+	t.Run("Shape", func(t *testing.T) {
+		ldid, _ := New()
+		short := ldid.Short()
+
+		if !regexp.MustCompile(`^[0-9a-f]{8}…[0-9a-f]{4}$`).MatchString(short) {
+			t.Fatalf("Short() = %v, want 8 hex digits, an ellipsis and 4 hex digits", short)
+		}
+
+		s := ldid.String()
+		if !strings.HasPrefix(short, s[:8]) || !strings.HasSuffix(short, s[len(s)-4:]) {
+			t.Fatalf("Short() = %v, want prefix and suffix of %v", short, s)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if short := (*LDID)(nil).Short(); short != "" {
+			t.Fatalf("Short() = %v, want empty string", short)
+		}
+	})
+}
+
+func TestFilename(t *testing.T) {
+	t.Run("No forbidden characters", func(t *testing.T) {
+		ids, _ := NewBatch(1000)
+		valid := regexp.MustCompile(`^[0-9a-v]{26}\.json$`)
+
+		for _, ldid := range ids {
+			name := ldid.Filename("json")
+
+			if !valid.MatchString(name) {
+				t.Fatalf("Filename() = %v, want only digits and lowercase letters", name)
+			}
+
+			if strings.ContainsAny(name, `<>:"/\|?*`) {
+				t.Fatalf("Filename() = %v, want no characters reserved on Windows", name)
+			}
+		}
+	})
+
+	t.Run("Extension", func(t *testing.T) {
+		ldid, _ := FromString("018cc251-f400-7abc-8def-0123456789ab")
+
+		tests := []struct {
+			ext      string
+			expected string
+		}{
+			{"json", ldid.Base32() + ".json"},
+			{".json", ldid.Base32() + ".json"},
+			{"", ldid.Base32()},
+		}
+
+		for _, tt := range tests {
+			if name := ldid.Filename(tt.ext); name != tt.expected {
+				t.Fatalf("Filename(%q) = %v, want %v", tt.ext, name, tt.expected)
+			}
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if name := (*LDID)(nil).Filename("json"); name != "" {
+			t.Fatalf("Filename() = %v, want empty string", name)
+		}
+	})
+}
+
+func TestDecompose(t *testing.T) {
+	t.Run("Known ID", func(t *testing.T) {
+		ldid, _ := FromString("018cc820-d888-7abc-8000-00000000002a")
+
+		expected := map[string]any{
+			"timestamp": uint64(1704164645000),
+			"time":      "2024-01-02T03:04:05Z",
+			"version":   uint64(7),
+			"rand_a":    uint64(0xabc),
+			"variant":   uint64(0b10),
+			"rand_b":    uint64(42),
+		}
+
+		fields, err := ldid.Decompose()
+		if err != nil {
+			t.Fatalf("Decompose() error = %v, wantErr %v", err, false)
+		}
+
+		if !reflect.DeepEqual(fields, expected) {
+			t.Fatalf("Decompose() = %v, want %v", fields, expected)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, err := (*LDID)(nil).Decompose(); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("Decompose() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestBitString(t *testing.T) {
+	t.Run("Known ID", func(t *testing.T) {
+		ldid, _ := FromString("018cc820-d888-7abc-8000-00000000002a")
+
+		expected := "timestamp=000000011000110011001000001000001101100010001000" +
+			" version=0111" +
+			" randA=101010111100" +
+			" variant=10" +
+			" randB=00000000000000000000000000000000000000000000000000000000101010"
+
+		bits, err := ldid.BitString()
+		if err != nil {
+			t.Fatalf("BitString() error = %v, wantErr %v", err, false)
+		}
+
+		if bits != expected {
+			t.Fatalf("BitString() = %v, want %v", bits, expected)
+		}
+	})
+
+	t.Run("Malformed ID", func(t *testing.T) {
+		ldid, _ := FromString("ffffffff-ffff-0fff-3fff-ffffffffffff")
+
+		bits, err := ldid.BitString()
+		if err != nil {
+			t.Fatalf("BitString() error = %v, wantErr %v", err, false)
+		}
+
+		if !strings.Contains(bits, " version=0000 ") || !strings.Contains(bits, " variant=00 ") {
+			t.Fatalf("BitString() = %v, want version=0000 and variant=00", bits)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, err := (*LDID)(nil).BitString(); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("BitString() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestLogValue(t *testing.T) {
+	ldid, _ := FromString("018cc251-f400-7abc-8def-0123456789ab")
+
+	tests := []struct {
+		name string
+		id   *LDID
+		want string
+	}{
+		{"LDID", ldid, "018cc251-f400-7abc-8def-0123456789ab"},
+		{"Nil LDID", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, nil))
+			logger.Info("request", slog.Any("id", tt.id))
+
+			var entry map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v, wantErr %v", err, false)
+			}
+
+			if entry["id"] != tt.want {
+				t.Fatalf("logged id = %v, want %v", entry["id"], tt.want)
+			}
+		})
+	}
+}
+
+func TestAgeStringAt(t *testing.T) {
+	now := time.Date(2024, time.January, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		age      time.Duration
+		expected string
+	}{
+		{"Future", -time.Hour, "just now"},
+		{"Seconds", 59 * time.Second, "just now"},
+		{"Minutes", 3*time.Minute + 30*time.Second, "3m ago"},
+		{"Hours", 2*time.Hour + 59*time.Minute, "2h ago"},
+		{"Yesterday", 30 * time.Hour, "yesterday"},
+		{"Days", 5*24*time.Hour + time.Hour, "5d ago"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			age, err := TestID(now.Add(-tt.age), 0).AgeStringAt(now)
+			if err != nil {
+				t.Fatalf("AgeStringAt() error = %v, wantErr %v", err, false)
+			}
+
+			if age != tt.expected {
+				t.Fatalf("AgeStringAt() = %v, want %v", age, tt.expected)
+			}
+		})
+	}
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, err := (*LDID)(nil).AgeString(); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("AgeString() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
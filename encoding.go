@@ -0,0 +1,358 @@
+package id
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// base32Alphabet is the lowercase base32 alphabet with extended hex digits (RFC 4648 section 7).
+// Unlike the standard alphabet it preserves the sort order of the encoded bytes.
+const base32Alphabet = "0123456789abcdefghijklmnopqrstuv"
+
+// base32Encoding is the unpadded encoding with base32Alphabet.
+var base32Encoding = base32.NewEncoding(base32Alphabet).WithPadding(base32.NoPadding)
+
+// maxValue is the largest value that fits in the 128 bits of an LDID.
+var maxValue = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+
+// MarshalJSON encodes the LDID as a JSON string in its canonical representation.
+func (id *LDID) MarshalJSON() ([]byte, error) {
+	if id.isNil() {
+		return nil, fmt.Errorf("failed to marshal LDID: %w", ErrNilLDID)
+	}
+
+	b := make([]byte, 0, CanonicalLength+2)
+	b = append(b, '"')
+	b = id.AppendText(b)
+	b = append(b, '"')
+
+	return b, nil
+}
+
+// UnmarshalJSON decodes a JSON string in the canonical representation into the LDID. Like the
+// encoding/json decoders of other types, it leaves the LDID unchanged for a JSON null.
+func (id *LDID) UnmarshalJSON(data []byte) error {
+	if id == nil {
+		return fmt.Errorf("failed to unmarshal LDID: %w", ErrNilLDID)
+	}
+
+	if string(data) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("failed to unmarshal LDID: %w", err)
+	}
+
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+
+	id.set(parsed.bf)
+
+	return nil
+}
+
+// MarshalXML encodes the LDID as element text in its canonical representation.
+func (id *LDID) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if id.isNil() {
+		return fmt.Errorf("failed to marshal LDID: %w", ErrNilLDID)
+	}
+
+	return e.EncodeElement(id.String(), start)
+}
+
+// UnmarshalXML decodes element text in the canonical representation into the LDID, ignoring
+// surrounding whitespace. An empty element is an error rather than the Nil UUID; use a pointer
+// field and omit the element for an absent LDID.
+func (id *LDID) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	if id == nil {
+		return fmt.Errorf("failed to unmarshal LDID: %w", ErrNilLDID)
+	}
+
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return fmt.Errorf("failed to unmarshal LDID: %w", err)
+	}
+
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return fmt.Errorf("failed to unmarshal LDID: empty element <%s>", start.Name.Local)
+	}
+
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+
+	id.set(parsed.bf)
+
+	return nil
+}
+
+// MarshalJSONNumber encodes the LDID as its 128-bit unsigned integer value in base 10.
+//
+// The value is emitted as a quoted JSON string: JSON numbers are commonly decoded as
+// 64-bit floating point values, which cannot hold 128 bits without losing precision.
+func (id *LDID) MarshalJSONNumber() ([]byte, error) {
+	if id.isNil() {
+		return nil, fmt.Errorf("failed to marshal LDID: %w", ErrNilLDID)
+	}
+
+	return []byte(`"` + id.Decimal() + `"`), nil
+}
+
+// UnmarshalJSONNumber decodes the output of MarshalJSONNumber into the LDID.
+// An unquoted JSON number is accepted as well, as long as it is a non-negative integer;
+// unbalanced quotes are rejected.
+func (id *LDID) UnmarshalJSONNumber(data []byte) error {
+	if id == nil {
+		return fmt.Errorf("failed to unmarshal LDID: %w", ErrNilLDID)
+	}
+
+	s := string(data)
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+
+	b, err := decimalBytes(s)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal LDID: %w", err)
+	}
+
+	id.set(fromBytes(b).bf)
+
+	return nil
+}
+
+// Decimal returns the 128-bit unsigned integer value of the LDID in base 10, or an empty
+// string for a nil LDID.
+func (id *LDID) Decimal() string {
+	if id.isNil() {
+		return ""
+	}
+
+	return new(big.Int).SetBytes(id.bf.Bytes()).String()
+}
+
+// PaddedDecimal returns the 128-bit unsigned integer value of the LDID in base 10, padded with
+// leading zeros to the DecimalLength of 39 digits needed for 2^128-1. The fixed width makes the
+// strings sort lexically in numeric order, and so by creation time, e.g. in spreadsheets. It
+// returns an empty string for a nil LDID.
+func (id *LDID) PaddedDecimal() string {
+	d := id.Decimal()
+	if d == "" {
+		return ""
+	}
+
+	return strings.Repeat("0", DecimalLength-len(d)) + d
+}
+
+// FromDecimal parses the base 10 representation of a 128-bit unsigned integer, as returned by
+// Decimal, into a new LDID. Only ASCII digits are accepted, so signs are rejected, as are
+// values above 2^128-1.
+func FromDecimal(s string) (*LDID, error) {
+	b, err := decimalBytes(s)
+	if err != nil {
+		return &LDID{}, fmt.Errorf("failed to parse LDID: %w", err)
+	}
+
+	return fromBytes(b), nil
+}
+
+// decimalBytes converts a base 10 number in the range 0 to 2^128-1 into 16 big-endian bytes.
+// Only ASCII digits are accepted, without the sign or underscores big.Int would allow.
+func decimalBytes(s string) ([]byte, error) {
+	if s == "" || strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+		return nil, fmt.Errorf("invalid decimal number %q", s)
+	}
+
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal number %q", s)
+	}
+
+	if n.Cmp(maxValue) > 0 {
+		return nil, fmt.Errorf("%s does not fit in 128 bits", s)
+	}
+
+	return n.FillBytes(make([]byte, 16)), nil
+}
+
+// AppendText appends the canonical string representation of the LDID to b and returns
+// the extended buffer. It does not allocate when b has enough spare capacity.
+func (id *LDID) AppendText(b []byte) []byte {
+	if id.isNil() {
+		return b
+	}
+
+	src := id.bf.Bytes()
+
+	n := len(b)
+	b = append(b, make([]byte, CanonicalLength)...)
+	dst := b[n:]
+
+	hex.Encode(dst[0:8], src[0:4])
+	dst[8] = '-'
+	hex.Encode(dst[9:13], src[4:6])
+	dst[13] = '-'
+	hex.Encode(dst[14:18], src[6:8])
+	dst[18] = '-'
+	hex.Encode(dst[19:23], src[8:10])
+	dst[23] = '-'
+	hex.Encode(dst[24:36], src[10:16])
+
+	return b
+}
+
+// AppendBinary appends the raw bytes of the LDID to b and returns the extended buffer.
+func (id *LDID) AppendBinary(b []byte) []byte {
+	if id.isNil() {
+		return b
+	}
+
+	return append(b, id.bf.Bytes()...)
+}
+
+// HexSortable returns the LDID as 32 lowercase hex characters without hyphens. Leading zeros
+// are preserved, so the strings sort lexically in the same order as the underlying bytes,
+// unlike the output of big.Int.Text(16). It returns an empty string for a nil LDID.
+func (id *LDID) HexSortable() string {
+	if id.isNil() {
+		return ""
+	}
+
+	return hex.EncodeToString(id.bf.Bytes())
+}
+
+// Base32 returns the LDID as 26 characters of unpadded base32 with the lowercase extended hex
+// alphabet, which sorts lexically in the same order as the underlying bytes. It returns an
+// empty string for a nil LDID.
+func (id *LDID) Base32() string {
+	if id.isNil() {
+		return ""
+	}
+
+	return base32Encoding.EncodeToString(id.bf.Bytes())
+}
+
+// Base64 returns the LDID as 22 characters of unpadded URL-safe base64. Unlike Base32, the
+// strings do not sort in the order of the underlying bytes. It returns an empty string for a
+// nil LDID.
+func (id *LDID) Base64() string {
+	if id.isNil() {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(id.bf.Bytes())
+}
+
+// RandomBase64 returns only the 74 random bits of the LDID as 14 characters of unpadded
+// URL-safe base64, e.g. as a deduplication key that ignores the timestamp. The bits are packed
+// big-endian into 10 bytes: the 12 bits of RandA, then the 62 bits of RandB, then 6 zero bits.
+// Use SameRandom to compare the random bits of two LDIDs directly. It returns an empty string
+// for a nil LDID.
+func (id *LDID) RandomBase64() string {
+	randA, err := id.RandA()
+	if err != nil {
+		return ""
+	}
+
+	randB, err := id.RandB()
+	if err != nil {
+		return ""
+	}
+
+	b := make([]byte, 10)
+	binary.BigEndian.PutUint64(b[0:8], randA<<52|randB>>10)
+	binary.BigEndian.PutUint16(b[8:10], uint16(randB&(1<<10-1))<<6)
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// ProtoBytes returns a copy of the 16 raw bytes of the LDID for assignment to a protobuf bytes
+// field. It returns nil for a nil LDID.
+func (id *LDID) ProtoBytes() []byte {
+	return id.AppendBinary(nil)
+}
+
+// LDIDFromProtoBytes creates a new LDID from a protobuf bytes field, which must hold exactly
+// 16 bytes. An unset field (nil or empty) is an error rather than the Nil UUID.
+func LDIDFromProtoBytes(b []byte) (*LDID, error) {
+	if len(b) != ByteLength {
+		return &LDID{}, fmt.Errorf("failed to read LDID from protobuf bytes: got %d bytes, want %d", len(b), ByteLength)
+	}
+
+	return fromBytes(b), nil
+}
+
+// HighInt64 returns the first 8 bytes of the LDID as a big-endian int64, for storage in signed
+// 64-bit columns such as a Java long or SQL BIGINT. The bits are reinterpreted as two's
+// complement, so values with the top bit set are negative and signed comparisons do not
+// preserve the byte order of the LDID. It returns 0 for a nil LDID.
+func (id *LDID) HighInt64() int64 {
+	if id.isNil() {
+		return 0
+	}
+
+	return int64(binary.BigEndian.Uint64(id.bf.Bytes()[0:8]))
+}
+
+// LowInt64 returns the last 8 bytes of the LDID as a big-endian int64, with the same
+// reinterpretation as HighInt64. It returns 0 for a nil LDID.
+func (id *LDID) LowInt64() int64 {
+	if id.isNil() {
+		return 0
+	}
+
+	return int64(binary.BigEndian.Uint64(id.bf.Bytes()[8:16]))
+}
+
+// FromInt64s creates a new LDID from the values returned by HighInt64 and LowInt64.
+func FromInt64s(high, low int64) *LDID {
+	return fromHalves(uint64(high), uint64(low))
+}
+
+// fromHalves creates a new LDID from the big-endian high and low 64 bits of its value.
+func fromHalves(hi, lo uint64) *LDID {
+	b := make([]byte, ByteLength)
+	binary.BigEndian.PutUint64(b[0:8], hi)
+	binary.BigEndian.PutUint64(b[8:16], lo)
+
+	return fromBytes(b)
+}
+
+// TimestampBE returns the 6 timestamp bytes of the LDID, which hold the value of Timestamp as a
+// 48-bit big-endian unsigned integer, as stored. It returns nil for a nil LDID.
+func (id *LDID) TimestampBE() []byte {
+	if id.isNil() {
+		return nil
+	}
+
+	return append([]byte(nil), id.bf.Bytes()[0:6]...)
+}
+
+// WithTimestampBE returns a copy of the LDID with the timestamp replaced by 6 big-endian bytes
+// in the format returned by TimestampBE. The LDID itself is not modified.
+func (id *LDID) WithTimestampBE(b []byte) (*LDID, error) {
+	if id.isNil() {
+		return &LDID{}, ErrNilLDID
+	}
+
+	if len(b) != 6 {
+		return &LDID{}, fmt.Errorf("failed to set timestamp: got %d bytes, want 6", len(b))
+	}
+
+	updated := append(append([]byte(nil), b...), id.bf.Bytes()[6:]...)
+
+	return fromBytes(updated), nil
+}
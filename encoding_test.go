@@ -0,0 +1,667 @@
+package id
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"sort"
+	"testing"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	t.Run("Round trip", func(t *testing.T) {
+		ldid, err := New()
+		if err != nil {
+			t.Fatalf("New() error = %v, wantErr %v", err, false)
+		}
+
+		data, err := json.Marshal(ldid)
+		if err != nil {
+			t.Fatalf("json.Marshal() error = %v, wantErr %v", err, false)
+		}
+
+		if string(data) != `"`+ldid.String()+`"` {
+			t.Fatalf("json.Marshal() = %s, want %q", data, ldid.String())
+		}
+
+		decoded := &LDID{}
+		if err := json.Unmarshal(data, decoded); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v, wantErr %v", err, false)
+		}
+
+		if decoded.String() != ldid.String() {
+			t.Fatalf("json.Unmarshal() = %v, want %v", decoded, ldid)
+		}
+	})
+
+	t.Run("Null", func(t *testing.T) {
+		var record struct {
+			ID LDID `json:"id"`
+		}
+
+		ldid, _ := New()
+		record.ID.set(ldid.bf)
+
+		if err := json.Unmarshal([]byte(`{"id":null}`), &record); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v, wantErr %v", err, false)
+		}
+
+		if record.ID.String() != ldid.String() {
+			t.Fatalf("json.Unmarshal() = %v, want %v unchanged", record.ID.String(), ldid)
+		}
+	})
+
+	t.Run("Invalid input", func(t *testing.T) {
+		for _, input := range []string{`123`, `"not-an-id"`, `"018cc251f4007abc8def0123456789ab"`} {
+			if err := json.Unmarshal([]byte(input), &LDID{}); err == nil {
+				t.Fatalf("json.Unmarshal(%s) error = %v, wantErr true", input, err)
+			}
+		}
+	})
+}
+
+func TestMarshalXML(t *testing.T) {
+	type envelope struct {
+		XMLName xml.Name `xml:"envelope"`
+		ID      *LDID    `xml:"id"`
+	}
+
+	t.Run("Round trip", func(t *testing.T) {
+		ldid, _ := New()
+
+		data, err := xml.Marshal(envelope{ID: ldid})
+		if err != nil {
+			t.Fatalf("xml.Marshal() error = %v, wantErr %v", err, false)
+		}
+
+		expected := "<envelope><id>" + ldid.String() + "</id></envelope>"
+		if string(data) != expected {
+			t.Fatalf("xml.Marshal() = %s, want %s", data, expected)
+		}
+
+		var decoded envelope
+		if err := xml.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("xml.Unmarshal() error = %v, wantErr %v", err, false)
+		}
+
+		if decoded.ID.String() != ldid.String() {
+			t.Fatalf("xml.Unmarshal() = %v, want %v", decoded.ID, ldid)
+		}
+	})
+
+	t.Run("Surrounding whitespace", func(t *testing.T) {
+		input := "<envelope><id>\n  018cc251-f400-7abc-8def-0123456789ab\n</id></envelope>"
+
+		var decoded envelope
+		if err := xml.Unmarshal([]byte(input), &decoded); err != nil {
+			t.Fatalf("xml.Unmarshal() error = %v, wantErr %v", err, false)
+		}
+
+		if decoded.ID.String() != "018cc251-f400-7abc-8def-0123456789ab" {
+			t.Fatalf("xml.Unmarshal() = %v, want %v", decoded.ID, "018cc251-f400-7abc-8def-0123456789ab")
+		}
+	})
+
+	t.Run("Invalid input", func(t *testing.T) {
+		inputs := []string{
+			"<envelope><id></id></envelope>",
+			"<envelope><id/></envelope>",
+			"<envelope><id>not-an-id</id></envelope>",
+		}
+
+		for _, input := range inputs {
+			var decoded envelope
+			if err := xml.Unmarshal([]byte(input), &decoded); err == nil {
+				t.Fatalf("xml.Unmarshal(%s) error = %v, wantErr true", input, err)
+			}
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, err := xml.Marshal(&LDID{}); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("xml.Marshal() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestMarshalJSONNumber(t *testing.T) {
+	t.Run("Round trip", func(t *testing.T) {
+		ldid, err := New()
+		if err != nil {
+			t.Fatalf("New() error = %v, wantErr %v", err, false)
+		}
+
+		data, err := ldid.MarshalJSONNumber()
+		if err != nil {
+			t.Fatalf("MarshalJSONNumber() error = %v, wantErr %v", err, false)
+		}
+
+		decoded := &LDID{}
+		if err := decoded.UnmarshalJSONNumber(data); err != nil {
+			t.Fatalf("UnmarshalJSONNumber() error = %v, wantErr %v", err, false)
+		}
+
+		if !bytes.Equal(decoded.Bytes(), ldid.Bytes()) {
+			t.Fatalf("UnmarshalJSONNumber() = %v, want %v", decoded, ldid)
+		}
+	})
+
+	t.Run("Quoted output", func(t *testing.T) {
+		ldid := fromBytes([]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0})
+		expected := `"256"`
+
+		data, err := ldid.MarshalJSONNumber()
+		if err != nil {
+			t.Fatalf("MarshalJSONNumber() error = %v, wantErr %v", err, false)
+		}
+
+		if string(data) != expected {
+			t.Fatalf("MarshalJSONNumber() = %s, want %s", data, expected)
+		}
+	})
+
+	t.Run("Unquoted input", func(t *testing.T) {
+		expected := []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0}
+
+		decoded := &LDID{}
+		if err := decoded.UnmarshalJSONNumber([]byte(`256`)); err != nil {
+			t.Fatalf("UnmarshalJSONNumber() error = %v, wantErr %v", err, false)
+		}
+
+		if !bytes.Equal(decoded.Bytes(), expected) {
+			t.Fatalf("UnmarshalJSONNumber() = %v, want %v", decoded.Bytes(), expected)
+		}
+	})
+
+	t.Run("Invalid input", func(t *testing.T) {
+		inputs := []string{
+			`""`,
+			`"abc"`,
+			`"-1"`,
+			`"340282366920938463463374607431768211456"`, // 2^128
+			`"256`,
+			`256"`,
+			`"`,
+			`"+5"`,
+			`+5`,
+			`"-0"`,
+		}
+
+		for _, input := range inputs {
+			if err := (&LDID{}).UnmarshalJSONNumber([]byte(input)); err == nil {
+				t.Fatalf("UnmarshalJSONNumber(%s) error = %v, wantErr true", input, err)
+			}
+		}
+	})
+}
+
+func TestDecimal(t *testing.T) {
+	t.Run("Round trip", func(t *testing.T) {
+		ldid, _ := New()
+
+		parsed, err := FromDecimal(ldid.Decimal())
+		if err != nil {
+			t.Fatalf("FromDecimal() error = %v, wantErr %v", err, false)
+		}
+
+		if !bytes.Equal(parsed.Bytes(), ldid.Bytes()) {
+			t.Fatalf("FromDecimal() = %v, want %v", parsed, ldid)
+		}
+	})
+
+	t.Run("Boundaries", func(t *testing.T) {
+		tests := []struct {
+			decimal string
+			want    string
+		}{
+			{"0", "00000000-0000-0000-0000-000000000000"},
+			{"340282366920938463463374607431768211455", "ffffffff-ffff-ffff-ffff-ffffffffffff"}, // 2^128-1
+		}
+
+		for _, tt := range tests {
+			ldid, err := FromDecimal(tt.decimal)
+			if err != nil {
+				t.Fatalf("FromDecimal(%v) error = %v, wantErr %v", tt.decimal, err, false)
+			}
+
+			if ldid.String() != tt.want {
+				t.Fatalf("FromDecimal(%v) = %v, want %v", tt.decimal, ldid, tt.want)
+			}
+
+			if decimal := ldid.Decimal(); decimal != tt.decimal {
+				t.Fatalf("Decimal() = %v, want %v", decimal, tt.decimal)
+			}
+		}
+	})
+
+	t.Run("Invalid input", func(t *testing.T) {
+		inputs := []string{
+			"",
+			"abc",
+			"0x10",
+			"-1",
+			"-0",
+			"+5",
+			"1_000",
+			" 5",
+			"340282366920938463463374607431768211456", // 2^128
+		}
+
+		for _, input := range inputs {
+			if _, err := FromDecimal(input); err == nil {
+				t.Fatalf("FromDecimal(%q) error = %v, wantErr true", input, err)
+			}
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if decimal := (*LDID)(nil).Decimal(); decimal != "" {
+			t.Fatalf("Decimal() = %v, want empty string", decimal)
+		}
+	})
+}
+
+func TestPaddedDecimal(t *testing.T) {
+	small := fromBytes([]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0})
+	max, _ := Parse("ffffffff-ffff-ffff-ffff-ffffffffffff")
+
+	t.Run("Fixed width", func(t *testing.T) {
+		tests := []struct {
+			id       *LDID
+			expected string
+		}{
+			{small, "000000000000000000000000000000000000256"},
+			{max, "340282366920938463463374607431768211455"},
+		}
+
+		for _, tt := range tests {
+			if d := tt.id.PaddedDecimal(); d != tt.expected {
+				t.Fatalf("PaddedDecimal() = %v, want %v", d, tt.expected)
+			}
+		}
+	})
+
+	t.Run("Sorts numerically", func(t *testing.T) {
+		ids, _ := NewBatch(50)
+		ids = append(ids, small, max)
+
+		strs := make([]string, len(ids))
+		for i, ldid := range ids {
+			strs[i] = ldid.PaddedDecimal()
+		}
+
+		sort.Slice(ids, func(i, j int) bool { return bytes.Compare(ids[i].Bytes(), ids[j].Bytes()) < 0 })
+		sort.Strings(strs)
+
+		for i, ldid := range ids {
+			if strs[i] != ldid.PaddedDecimal() {
+				t.Fatalf("sorted PaddedDecimal()[%d] = %v, want %v", i, strs[i], ldid.PaddedDecimal())
+			}
+		}
+
+		if strs[0] != small.PaddedDecimal() {
+			t.Fatalf("sorted PaddedDecimal()[0] = %v, want %v", strs[0], small.PaddedDecimal())
+		}
+	})
+
+	t.Run("Round trip", func(t *testing.T) {
+		parsed, err := FromDecimal(small.PaddedDecimal())
+		if err != nil {
+			t.Fatalf("FromDecimal() error = %v, wantErr %v", err, false)
+		}
+
+		if parsed.String() != small.String() {
+			t.Fatalf("FromDecimal() = %v, want %v", parsed, small)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if d := (*LDID)(nil).PaddedDecimal(); d != "" {
+			t.Fatalf("PaddedDecimal() = %v, want empty string", d)
+		}
+	})
+}
+
+func TestAppendText(t *testing.T) {
+	ldid, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v, wantErr %v", err, false)
+	}
+
+	t.Run("Matches String", func(t *testing.T) {
+		expected := "id=" + ldid.String()
+
+		if text := ldid.AppendText([]byte("id=")); string(text) != expected {
+			t.Fatalf("AppendText() = %s, want %s", text, expected)
+		}
+	})
+
+	t.Run("No allocations", func(t *testing.T) {
+		buf := make([]byte, 0, CanonicalLength)
+
+		allocs := testing.AllocsPerRun(100, func() {
+			buf = ldid.AppendText(buf[:0])
+		})
+
+		if allocs != 0 {
+			t.Fatalf("AppendText() allocs = %v, want %v", allocs, 0)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		var nilID *LDID
+
+		if text := nilID.AppendText([]byte("id=")); string(text) != "id=" {
+			t.Fatalf("AppendText() = %s, want %s", text, "id=")
+		}
+	})
+}
+
+func TestAppendBinary(t *testing.T) {
+	ldid, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v, wantErr %v", err, false)
+	}
+
+	expected := append([]byte{0xff}, ldid.Bytes()...)
+
+	if b := ldid.AppendBinary([]byte{0xff}); !bytes.Equal(b, expected) {
+		t.Fatalf("AppendBinary() = %v, want %v", b, expected)
+	}
+}
+
+func BenchmarkString(b *testing.B) {
+	ldid, _ := New()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = ldid.String()
+	}
+}
+
+func BenchmarkAppendText(b *testing.B) {
+	ldid, _ := New()
+	buf := make([]byte, 0, CanonicalLength)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = ldid.AppendText(buf[:0])
+	}
+}
+
+func TestHexSortable(t *testing.T) {
+	t.Run("Leading zeros", func(t *testing.T) {
+		ldid := newFromFields(1, 0, 0)
+		expected := "00000000000170008000000000000000"
+
+		if h := ldid.HexSortable(); h != expected {
+			t.Fatalf("HexSortable() = %v, want %v", h, expected)
+		}
+	})
+
+	t.Run("Sort order", func(t *testing.T) {
+		ids := []*LDID{
+			newFromFields(0x018cc251f400, 0xabc, 0x1),
+			newFromFields(1, 0, 0),
+			newFromFields(0x10, 0xfff, 0x3fffffffffffffff),
+			newFromFields(0xff, 0, 0),
+			newFromFields(0x018cc251f400, 0xabc, 0x0),
+		}
+
+		byHex := append([]*LDID{}, ids...)
+		sort.Slice(byHex, func(i, j int) bool {
+			return byHex[i].HexSortable() < byHex[j].HexSortable()
+		})
+
+		byBytes := append([]*LDID{}, ids...)
+		sort.Slice(byBytes, func(i, j int) bool {
+			return bytes.Compare(byBytes[i].Bytes(), byBytes[j].Bytes()) < 0
+		})
+
+		for i := range ids {
+			if byHex[i] != byBytes[i] {
+				t.Fatalf("HexSortable() order[%d] = %v, want %v", i, byHex[i], byBytes[i])
+			}
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if h := (*LDID)(nil).HexSortable(); h != "" {
+			t.Fatalf("HexSortable() = %v, want empty string", h)
+		}
+	})
+}
+
+func TestBase32(t *testing.T) {
+	t.Run("Known value", func(t *testing.T) {
+		ldid, _ := Parse("00000000-0000-0000-0000-000000000001")
+
+		if s := ldid.Base32(); s != "00000000000000000000000004" {
+			t.Fatalf("Base32() = %v, want %v", s, "00000000000000000000000004")
+		}
+	})
+
+	t.Run("Preserves sort order", func(t *testing.T) {
+		ids, _ := NewBatch(100)
+		strs := make([]string, len(ids))
+		for i, ldid := range ids {
+			if len(ldid.Base32()) != Base32Length {
+				t.Fatalf("Base32() len = %v, want %v", len(ldid.Base32()), Base32Length)
+			}
+			strs[i] = ldid.Base32()
+		}
+
+		sort.Slice(ids, func(i, j int) bool { return bytes.Compare(ids[i].Bytes(), ids[j].Bytes()) < 0 })
+		sort.Strings(strs)
+
+		for i, ldid := range ids {
+			if strs[i] != ldid.Base32() {
+				t.Fatalf("sorted Base32()[%d] = %v, want %v", i, strs[i], ldid.Base32())
+			}
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if s := (*LDID)(nil).Base32(); s != "" {
+			t.Fatalf("Base32() = %v, want empty string", s)
+		}
+	})
+}
+
+func TestBase64(t *testing.T) {
+	t.Run("Known value", func(t *testing.T) {
+		ldid, _ := Parse("ffffffff-ffff-ffff-ffff-fffffffffffe")
+
+		if s := ldid.Base64(); s != "_____________________g" {
+			t.Fatalf("Base64() = %v, want %v", s, "_____________________g")
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if s := (*LDID)(nil).Base64(); s != "" {
+			t.Fatalf("Base64() = %v, want empty string", s)
+		}
+	})
+}
+
+func TestRandomBase64(t *testing.T) {
+	t.Run("Deterministic", func(t *testing.T) {
+		ldid, _ := New()
+		other, _ := FromString(ldid.String())
+
+		if a, b := ldid.RandomBase64(), other.RandomBase64(); a != b || len(a) != 14 {
+			t.Fatalf("RandomBase64() = %v and %v, want equal 14 character strings", a, b)
+		}
+	})
+
+	t.Run("Packing", func(t *testing.T) {
+		// RandA 0xabc and RandB 0x3fffffffffffffff pack to abc followed by 62 one bits
+		ldid, _ := Parse("00000000-0000-7abc-bfff-ffffffffffff")
+
+		if s := ldid.RandomBase64(); s != "q8__________wA" {
+			t.Fatalf("RandomBase64() = %v, want %v", s, "q8__________wA")
+		}
+	})
+
+	t.Run("Ignores timestamp, version and variant", func(t *testing.T) {
+		a, _ := Parse("018cc251-f400-7abc-8def-0123456789ab")
+		b, _ := Parse("ffffffff-ffff-4abc-cdef-0123456789ab")
+		c, _ := Parse("018cc251-f400-7abc-8def-0123456789ac")
+
+		if a.RandomBase64() != b.RandomBase64() {
+			t.Fatalf("RandomBase64() = %v, want %v", b.RandomBase64(), a.RandomBase64())
+		}
+
+		if same, _ := a.SameRandom(b); !same {
+			t.Fatalf("SameRandom() = %v, want %v", same, true)
+		}
+
+		if a.RandomBase64() == c.RandomBase64() {
+			t.Fatalf("RandomBase64() = %v for different random bits", c.RandomBase64())
+		}
+
+		if same, _ := a.SameRandom(c); same {
+			t.Fatalf("SameRandom() = %v, want %v", same, false)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if s := (*LDID)(nil).RandomBase64(); s != "" {
+			t.Fatalf("RandomBase64() = %v, want empty string", s)
+		}
+
+		if _, err := (*LDID)(nil).SameRandom(&LDID{}); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("SameRandom() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestProtoBytes(t *testing.T) {
+	t.Run("Round trip", func(t *testing.T) {
+		ldid, _ := New()
+
+		b := ldid.ProtoBytes()
+		if len(b) != ByteLength {
+			t.Fatalf("ProtoBytes() len = %v, want %v", len(b), ByteLength)
+		}
+
+		decoded, err := LDIDFromProtoBytes(b)
+		if err != nil {
+			t.Fatalf("LDIDFromProtoBytes() error = %v, wantErr %v", err, false)
+		}
+
+		if decoded.String() != ldid.String() {
+			t.Fatalf("LDIDFromProtoBytes() = %v, want %v", decoded, ldid)
+		}
+	})
+
+	t.Run("Copy", func(t *testing.T) {
+		ldid, _ := New()
+		expected := ldid.String()
+
+		b := ldid.ProtoBytes()
+		b[0] ^= 0xff
+
+		if ldid.String() != expected {
+			t.Fatalf("String() = %v after modifying ProtoBytes(), want %v", ldid, expected)
+		}
+	})
+
+	t.Run("Invalid length", func(t *testing.T) {
+		for _, b := range [][]byte{nil, {}, make([]byte, 15), make([]byte, 17)} {
+			if _, err := LDIDFromProtoBytes(b); err == nil {
+				t.Fatalf("LDIDFromProtoBytes(%v) error = %v, wantErr true", b, err)
+			}
+		}
+	})
+}
+
+func TestInt64s(t *testing.T) {
+	t.Run("Round trip", func(t *testing.T) {
+		ldid, _ := New()
+
+		if decoded := FromInt64s(ldid.HighInt64(), ldid.LowInt64()); decoded.String() != ldid.String() {
+			t.Fatalf("FromInt64s() = %v, want %v", decoded, ldid)
+		}
+	})
+
+	t.Run("Sign reinterpretation", func(t *testing.T) {
+		ldid, _ := FromString("018cc251-f400-7abc-ffff-ffffffffffff")
+
+		if high := ldid.HighInt64(); high != 0x018cc251f4007abc {
+			t.Fatalf("HighInt64() = %v, want %v", high, int64(0x018cc251f4007abc))
+		}
+
+		if low := ldid.LowInt64(); low != -1 {
+			t.Fatalf("LowInt64() = %v, want %v", low, -1)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		var ldid *LDID
+
+		if ldid.HighInt64() != 0 || ldid.LowInt64() != 0 {
+			t.Fatalf("HighInt64(), LowInt64() = %v, %v, want 0, 0", ldid.HighInt64(), ldid.LowInt64())
+		}
+	})
+}
+
+func TestTimestampBE(t *testing.T) {
+	t.Run("Matches Timestamp", func(t *testing.T) {
+		ldid, _ := FromString("018cc251-f400-7abc-8def-0123456789ab")
+		expected := []byte{0x01, 0x8c, 0xc2, 0x51, 0xf4, 0x00}
+
+		b := ldid.TimestampBE()
+		if !bytes.Equal(b, expected) {
+			t.Fatalf("TimestampBE() = %x, want %x", b, expected)
+		}
+
+		timestamp, _ := ldid.Timestamp()
+		if v := uint64(b[0])<<40 | uint64(b[1])<<32 | uint64(b[2])<<24 | uint64(b[3])<<16 | uint64(b[4])<<8 | uint64(b[5]); v != timestamp {
+			t.Fatalf("TimestampBE() value = %v, want %v", v, timestamp)
+		}
+	})
+
+	t.Run("Round trip", func(t *testing.T) {
+		source, _ := New()
+		target, _ := FromString("018cc251-f400-7abc-8def-0123456789ab")
+		before := target.String()
+
+		updated, err := target.WithTimestampBE(source.TimestampBE())
+		if err != nil {
+			t.Fatalf("WithTimestampBE() error = %v, wantErr %v", err, false)
+		}
+
+		sourceTimestamp, _ := source.Timestamp()
+		if timestamp, _ := updated.Timestamp(); timestamp != sourceTimestamp {
+			t.Fatalf("WithTimestampBE() timestamp = %v, want %v", timestamp, sourceTimestamp)
+		}
+
+		if !bytes.Equal(updated.Bytes()[6:], target.Bytes()[6:]) {
+			t.Fatalf("WithTimestampBE() = %v, want remaining bytes of %v", updated, target)
+		}
+
+		if target.String() != before {
+			t.Fatalf("WithTimestampBE() modified LDID to %v, want %v", target, before)
+		}
+	})
+
+	t.Run("Invalid length", func(t *testing.T) {
+		ldid, _ := New()
+
+		if _, err := ldid.WithTimestampBE(make([]byte, 8)); err == nil {
+			t.Fatalf("WithTimestampBE() error = %v, wantErr true", err)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if b := (*LDID)(nil).TimestampBE(); b != nil {
+			t.Fatalf("TimestampBE() = %x, want nil", b)
+		}
+
+		if _, err := (*LDID)(nil).WithTimestampBE(make([]byte, 6)); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("WithTimestampBE() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
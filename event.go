@@ -0,0 +1,15 @@
+package id
+
+import "time"
+
+// ForEvent returns the LDID of an event in an event log, with the timestamp taken from the
+// recorded event time and the sequence number of the event stored in place of random data.
+// The result only depends on the millisecond of eventTime and on eventSeq, so replaying the
+// log reproduces the same LDIDs, and LDIDs of events in the same millisecond sort by sequence.
+//
+// The sequence is stored as a 74 bit big-endian number across RandA and RandB. Distinct events
+// must therefore have distinct times or sequence numbers, and as the LDIDs contain no random
+// data they are predictable; do not use them where unguessability matters.
+func ForEvent(eventTime time.Time, eventSeq uint64) *LDID {
+	return newFromFields(uint64(eventTime.UnixMilli()), eventSeq>>randBSize, eventSeq&(1<<randBSize-1))
+}
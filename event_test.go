@@ -0,0 +1,52 @@
+package id
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestForEvent(t *testing.T) {
+	eventTime := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("Replays produce identical IDs", func(t *testing.T) {
+		replay := func() []string {
+			var ids []string
+			for seq := uint64(0); seq < 100; seq++ {
+				ids = append(ids, ForEvent(eventTime.Add(time.Duration(seq/10)*time.Second), seq).String())
+			}
+			return ids
+		}
+
+		first, second := replay(), replay()
+		for i := range first {
+			if first[i] != second[i] {
+				t.Fatalf("ForEvent() = %v on replay, want %v", second[i], first[i])
+			}
+		}
+	})
+
+	t.Run("Known ID", func(t *testing.T) {
+		ldid := ForEvent(eventTime.Add(123*time.Microsecond), 42)
+
+		if ldid.String() != "018cc820-d888-7000-8000-00000000002a" {
+			t.Fatalf("ForEvent() = %v, want %v", ldid, "018cc820-d888-7000-8000-00000000002a")
+		}
+
+		if !ldid.IsValid() {
+			t.Fatalf("IsValid() = %v, want %v", false, true)
+		}
+	})
+
+	t.Run("Sorts by sequence within a millisecond", func(t *testing.T) {
+		seqs := []uint64{0, 1, 1<<randBSize - 1, 1 << randBSize, math.MaxUint64}
+
+		for i := 1; i < len(seqs); i++ {
+			a, b := ForEvent(eventTime, seqs[i-1]), ForEvent(eventTime, seqs[i])
+
+			if c, _ := Compare(a, b); c != -1 {
+				t.Fatalf("Compare(ForEvent(%d), ForEvent(%d)) = %v, want %v", seqs[i-1], seqs[i], c, -1)
+			}
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package id_test
+
+import (
+	"fmt"
+	"time"
+
+	"go.loafoe.dev/id"
+)
+
+func ExampleTestID() {
+	ts := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	fmt.Println(id.TestID(ts, 1))
+	fmt.Println(id.TestID(ts, 2))
+	// Output:
+	// 018cc820-d888-7001-8000-000000000000
+	// 018cc820-d888-7002-8000-000000000000
+}
+
+func ExampleLDIDFromProtoBytes() {
+	// Event stands in for a generated protobuf message with a `bytes id = 1;` field.
+	type Event struct {
+		Id []byte
+	}
+
+	ldid, _ := id.FromString("018cc820-d888-7001-8000-000000000000")
+
+	msg := &Event{Id: ldid.ProtoBytes()}
+
+	decoded, err := id.LDIDFromProtoBytes(msg.Id)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(len(msg.Id))
+	fmt.Println(decoded)
+	// Output:
+	// 16
+	// 018cc820-d888-7001-8000-000000000000
+}
+
+func ExampleLDID_Obfuscate() {
+	ldid, _ := id.FromString("018cc820-d888-7001-8000-000000000000")
+
+	key1 := [16]byte{1}
+	key2 := [16]byte{2}
+
+	fmt.Println(ldid.Obfuscate(key1))
+	fmt.Println(ldid.Obfuscate(key2))
+
+	decoded, _ := id.Deobfuscate(ldid.Obfuscate(key1), key1)
+	fmt.Println(decoded)
+	// Output:
+	// mkQeuj0XDa2kpu6IuTDypQ
+	// 4xK6VXQ10vGKnsvOjtx12g
+	// 018cc820-d888-7001-8000-000000000000
+}
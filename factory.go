@@ -0,0 +1,333 @@
+package id
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// typeTagSize is the number of leftmost RandA bits holding the type tag.
+const typeTagSize uint64 = 4
+
+// MaxTypeTag is the largest type tag accepted by WithTypeTag.
+const MaxTypeTag = 1<<typeTagSize - 1
+
+// Size and offset of the counter stored by WithCounterFallback in RandB.
+const (
+	counterSize   uint64 = 24 // Size of the counter field in bits.
+	counterOffset uint64 = 72 // Offset of the counter field in bits.
+)
+
+// processCounter is the counter shared by all Factories using WithCounterFallback.
+var processCounter atomic.Uint64
+
+// maxForbiddenPrefixRetries is the number of times New regenerates the random data of an LDID
+// that starts with a forbidden prefix before giving up.
+const maxForbiddenPrefixRetries = 100
+
+// Factory creates LDIDs from a Generator with additional options applied, configured once
+// with NewFactory and reused for every LDID. A Factory is safe for concurrent use if its
+// generator is.
+type Factory struct {
+	generator Generator
+
+	hasTypeTag bool
+	typeTag    uint8
+
+	forbiddenPrefixes [][]byte
+
+	strictTimestamp bool
+
+	hasEnvironmentBit bool
+	environmentBit    bool
+
+	counterFallback bool
+
+	macKey []byte
+
+	maxDrift time.Duration
+	// driftMu guards the baseline of the last accepted timestamp and the monotonic clock
+	// reading taken with it.
+	driftMu       sync.Mutex
+	lastTimestamp uint64
+	lastMonotonic time.Time
+}
+
+// Option configures a Factory.
+type Option func(*Factory) error
+
+// NewFactory creates a new Factory with the given options. Without WithGenerator, the
+// default generator is used.
+func NewFactory(opts ...Option) (*Factory, error) {
+	f := &Factory{
+		generator: defaultGenerator,
+	}
+
+	for _, opt := range opts {
+		if err := opt(f); err != nil {
+			return nil, err
+		}
+	}
+
+	return f, nil
+}
+
+// WithGenerator sets the generator used by the Factory.
+func WithGenerator(g Generator) Option {
+	return func(f *Factory) error {
+		if g == nil {
+			return errors.New("invalid option: generator must not be nil")
+		}
+
+		f.generator = g
+
+		return nil
+	}
+}
+
+// WithTypeTag stores a type tag of up to MaxTypeTag in the leftmost 4 bits of RandA, to
+// distinguish kinds of entities by their ID. This reduces the random data of each LDID from
+// 74 to 70 bits. Read the tag back with TypeTag or HasTypeTag.
+func WithTypeTag(tag uint8) Option {
+	return func(f *Factory) error {
+		if tag > MaxTypeTag {
+			return fmt.Errorf("invalid option: type tag %d exceeds %d", tag, MaxTypeTag)
+		}
+
+		f.hasTypeTag = true
+		f.typeTag = tag
+
+		return nil
+	}
+}
+
+// WithForbiddenPrefixes makes the Factory regenerate the random data of any LDID that starts
+// with one of the given byte prefixes, keeping the timestamp. Each prefix must be 1 to 16 bytes.
+//
+// A prefix reaching past the timestamp bytes matches rarely, so regeneration is rare too. A
+// prefix within the first 6 bytes only depends on the timestamp and cannot be avoided by
+// regenerating; New returns an error when an LDID still matches after 100 retries.
+func WithForbiddenPrefixes(prefixes [][]byte) Option {
+	return func(f *Factory) error {
+		for _, prefix := range prefixes {
+			if len(prefix) < 1 || len(prefix) > ByteLength {
+				return fmt.Errorf("invalid option: forbidden prefix must be 1 to %d bytes long, got %d", ByteLength, len(prefix))
+			}
+
+			f.forbiddenPrefixes = append(f.forbiddenPrefixes, append([]byte(nil), prefix...))
+		}
+
+		return nil
+	}
+}
+
+// WithStrictTimestamp makes New return an error when the generator returns a timestamp that
+// does not fit in the 48-bit timestamp field, instead of truncating it like NewWithGenerator.
+func WithStrictTimestamp() Option {
+	return func(f *Factory) error {
+		f.strictTimestamp = true
+
+		return nil
+	}
+}
+
+// WithEnvironmentBit stores a flag in the leftmost bit of RandB, to tell apart LDIDs created in
+// different environments, such as staging (false) and production (true). This reduces the
+// random data of each LDID by one bit. Read the flag back with EnvironmentBit.
+func WithEnvironmentBit(set bool) Option {
+	return func(f *Factory) error {
+		f.hasEnvironmentBit = true
+		f.environmentBit = set
+
+		return nil
+	}
+}
+
+// WithMaxDrift makes New return an error when the generator's timestamp differs by more than d
+// from the timestamp expected by the monotonic clock, which is the previous timestamp plus the
+// monotonic time elapsed since. This refuses to create LDIDs after the wall clock jumps forward
+// or backward, e.g. when it is set wrong.
+//
+// The check needs the timestamp of the previous call, so the Factory keeps state: the first
+// call is always accepted, and refused calls keep the previous baseline, so generation resumes
+// once the clock is back within d of it. Use a new Factory to accept a deliberate clock change.
+func WithMaxDrift(d time.Duration) Option {
+	return func(f *Factory) error {
+		if d <= 0 {
+			return errors.New("invalid option: max drift must be positive")
+		}
+
+		f.maxDrift = d
+
+		return nil
+	}
+}
+
+// WithCounterFallback replaces bits 72 to 95 of each LDID, in RandB, with a counter shared by
+// all Factories in the process, so LDIDs stay unique within the process even when the source
+// of random data is weak or deterministic: two LDIDs created with this option in the same
+// millisecond always differ, unless more than 2^24 are created in it. LDIDs from different
+// processes get no such guarantee.
+//
+// This reduces the random data of each LDID from 74 to 50 bits. The counter is kept when
+// combined with the other options, which use different bits.
+func WithCounterFallback() Option {
+	return func(f *Factory) error {
+		f.counterFallback = true
+
+		return nil
+	}
+}
+
+// New creates a new LDID with the generator and options of the Factory.
+func (f *Factory) New() (*LDID, error) {
+	timestamp, err := generateTimestamp(f.generator)
+	if err != nil {
+		return &LDID{}, err
+	}
+
+	if f.strictTimestamp && timestamp>>timestampSize != 0 {
+		return &LDID{}, fmt.Errorf("failed to generate LDID: timestamp %d does not fit in %d bits", timestamp, timestampSize)
+	}
+
+	if err := f.checkDrift(timestamp); err != nil {
+		return &LDID{}, err
+	}
+
+	for retries := 0; ; retries++ {
+		id, err := f.newWithTimestamp(timestamp)
+		if err != nil {
+			return &LDID{}, err
+		}
+
+		if !f.forbidden(id) {
+			return id, nil
+		}
+
+		if retries == maxForbiddenPrefixRetries {
+			return &LDID{}, fmt.Errorf("failed to generate LDID: still matching a forbidden prefix after %d retries", retries)
+		}
+	}
+}
+
+// newWithTimestamp creates a new LDID with the given timestamp and fresh random data.
+func (f *Factory) newWithTimestamp(timestamp uint64) (*LDID, error) {
+	randA, err := f.generator.GenerateRandomBits(rand.Reader, int64(randASize))
+	if err != nil {
+		return &LDID{}, err
+	}
+	randB, err := f.generator.GenerateRandomBits(rand.Reader, int64(randBSize))
+	if err != nil {
+		return &LDID{}, err
+	}
+	randB = saltRandB(f.generator, randB)
+
+	if f.hasTypeTag {
+		shift := randASize - typeTagSize
+		randA = uint64(f.typeTag)<<shift | randA&(1<<shift-1)
+	}
+
+	if f.hasEnvironmentBit {
+		shift := randBSize - 1
+		randB &= 1<<shift - 1
+		if f.environmentBit {
+			randB |= 1 << shift
+		}
+	}
+
+	id := newFromFields(timestamp, randA, randB)
+
+	if f.counterFallback {
+		id.bf.InsertUint64(counterOffset, counterSize, processCounter.Add(1))
+	}
+
+	if f.macKey != nil {
+		id.bf.InsertUint64(macOffset, macSize, computeMAC(f.macKey, id.bf.Bytes()))
+	}
+
+	if err := id.bf.Error(); err != nil {
+		return &LDID{}, err
+	}
+
+	return id, nil
+}
+
+// checkDrift compares the timestamp against the monotonic baseline when WithMaxDrift is set,
+// and makes it the new baseline if it is accepted.
+func (f *Factory) checkDrift(timestamp uint64) error {
+	if f.maxDrift == 0 {
+		return nil
+	}
+
+	f.driftMu.Lock()
+	defer f.driftMu.Unlock()
+
+	now := time.Now()
+
+	if !f.lastMonotonic.IsZero() {
+		expected := int64(f.lastTimestamp) + now.Sub(f.lastMonotonic).Milliseconds()
+		drift := time.Duration(int64(timestamp)-expected) * time.Millisecond
+
+		if drift > f.maxDrift || drift < -f.maxDrift {
+			return fmt.Errorf("failed to generate LDID: clock drifted %v from the monotonic clock, exceeding %v", drift, f.maxDrift)
+		}
+	}
+
+	f.lastTimestamp = timestamp
+	f.lastMonotonic = now
+
+	return nil
+}
+
+// forbidden reports whether the LDID starts with one of the forbidden prefixes.
+func (f *Factory) forbidden(id *LDID) bool {
+	b := id.bf.Bytes()
+
+	for _, prefix := range f.forbiddenPrefixes {
+		if bytes.HasPrefix(b, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TypeTag returns the type tag stored in the leftmost 4 bits of RandA by WithTypeTag. LDIDs
+// created without a type tag return random values.
+func (id *LDID) TypeTag() (uint8, error) {
+	tag, err := id.extract(randAOffset, typeTagSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint8(tag), nil
+}
+
+// HasTypeTag reports whether the LDID carries the given type tag.
+func (id *LDID) HasTypeTag(tag uint8) (bool, error) {
+	if tag > MaxTypeTag {
+		return false, fmt.Errorf("invalid type tag: %d exceeds %d", tag, MaxTypeTag)
+	}
+
+	actual, err := id.TypeTag()
+	if err != nil {
+		return false, err
+	}
+
+	return actual == tag, nil
+}
+
+// EnvironmentBit returns the flag stored in the leftmost bit of RandB by WithEnvironmentBit.
+// LDIDs created without the option return a random value.
+func (id *LDID) EnvironmentBit() (bool, error) {
+	bit, err := id.extract(randBOffset, 1)
+	if err != nil {
+		return false, err
+	}
+
+	return bit == 1, nil
+}
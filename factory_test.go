@@ -0,0 +1,398 @@
+package id
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestNewFactory(t *testing.T) {
+	t.Run("Default generator", func(t *testing.T) {
+		f, err := NewFactory()
+		if err != nil {
+			t.Fatalf("NewFactory() error = %v, wantErr %v", err, false)
+		}
+
+		ldid, err := f.New()
+		if err != nil {
+			t.Fatalf("New() error = %v, wantErr %v", err, false)
+		}
+
+		if err := ldid.validate(); err != nil {
+			t.Fatalf("New() = %v, want valid LDID: %v", ldid, err)
+		}
+	})
+
+	t.Run("Nil generator", func(t *testing.T) {
+		if _, err := NewFactory(WithGenerator(nil)); err == nil {
+			t.Fatalf("NewFactory() error = %v, wantErr true", err)
+		}
+	})
+
+	t.Run("Generator failing", func(t *testing.T) {
+		m := &MockGenerator{
+			GenerateRandomBitsFunc: func(randReader io.Reader, n int64) (uint64, error) {
+				return 0, errors.New("mock error")
+			},
+		}
+
+		f, _ := NewFactory(WithGenerator(m))
+
+		if _, err := f.New(); err == nil {
+			t.Fatalf("New() error = %v, wantErr true", err)
+		}
+	})
+}
+
+func TestWithTypeTag(t *testing.T) {
+	t.Run("Round trip", func(t *testing.T) {
+		for _, tag := range []uint8{0, 5, MaxTypeTag} {
+			f, err := NewFactory(WithTypeTag(tag))
+			if err != nil {
+				t.Fatalf("NewFactory() error = %v, wantErr %v", err, false)
+			}
+
+			ldid, err := f.New()
+			if err != nil {
+				t.Fatalf("New() error = %v, wantErr %v", err, false)
+			}
+
+			if actual, _ := ldid.TypeTag(); actual != tag {
+				t.Fatalf("TypeTag() = %v, want %v", actual, tag)
+			}
+
+			if has, _ := ldid.HasTypeTag(tag); !has {
+				t.Fatalf("HasTypeTag(%d) = %v, want %v", tag, has, true)
+			}
+		}
+	})
+
+	t.Run("Keeps remaining random bits", func(t *testing.T) {
+		m := &MockGenerator{
+			GenerateRandomBitsFunc: func(randReader io.Reader, n int64) (uint64, error) {
+				return 0b101010101010, nil
+			},
+		}
+
+		f, _ := NewFactory(WithGenerator(m), WithTypeTag(0b0011))
+
+		ldid, err := f.New()
+		if err != nil {
+			t.Fatalf("New() error = %v, wantErr %v", err, false)
+		}
+
+		if randA, _ := ldid.RandA(); randA != 0b001110101010 {
+			t.Fatalf("RandA() = %b, want %b", randA, 0b001110101010)
+		}
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		f, _ := NewFactory(WithTypeTag(3))
+		ldid, _ := f.New()
+
+		if has, _ := ldid.HasTypeTag(4); has {
+			t.Fatalf("HasTypeTag(4) = %v, want %v", has, false)
+		}
+	})
+
+	t.Run("Tag out of range", func(t *testing.T) {
+		if _, err := NewFactory(WithTypeTag(MaxTypeTag + 1)); err == nil {
+			t.Fatalf("NewFactory() error = %v, wantErr true", err)
+		}
+
+		ldid, _ := New()
+		if _, err := ldid.HasTypeTag(MaxTypeTag + 1); err == nil {
+			t.Fatalf("HasTypeTag() error = %v, wantErr true", err)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, err := (*LDID)(nil).TypeTag(); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("TypeTag() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestWithForbiddenPrefixes(t *testing.T) {
+	now := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+	clock := func() time.Time {
+		return now
+	}
+	timestampBytes := TestID(now, 0).Bytes()[:6]
+
+	t.Run("Forces a retry", func(t *testing.T) {
+		// GenerateRandomBits reads 2 bytes for RandA and 8 bytes for RandB: the first attempt yields
+		// RandA 0x001, starting byte 6 with 0x70, and the second RandA 0xf00
+		random := []byte{0x00, 0x01, 0, 0, 0, 0, 0, 0, 0, 0, 0x0f, 0x00, 0, 0, 0, 0, 0, 0, 0, 0}
+		g := &DefaultGenerator{Clock: clock, Reader: bytes.NewReader(random)}
+
+		forbidden := append(append([]byte(nil), timestampBytes...), 0x70)
+		f, err := NewFactory(WithGenerator(g), WithForbiddenPrefixes([][]byte{forbidden}))
+		if err != nil {
+			t.Fatalf("NewFactory() error = %v, wantErr %v", err, false)
+		}
+
+		ldid, err := f.New()
+		if err != nil {
+			t.Fatalf("New() error = %v, wantErr %v", err, false)
+		}
+
+		if bytes.HasPrefix(ldid.Bytes(), forbidden) {
+			t.Fatalf("New() = %v, want no prefix %x", ldid, forbidden)
+		}
+
+		if randA, _ := ldid.RandA(); randA != 0xf00 {
+			t.Fatalf("New() RandA = %#x, want %#x from the retry", randA, 0xf00)
+		}
+
+		if timestamp, _ := ldid.Timestamp(); timestamp != uint64(now.UnixMilli()) {
+			t.Fatalf("New() timestamp = %v, want %v", timestamp, now.UnixMilli())
+		}
+	})
+
+	t.Run("Retry cap", func(t *testing.T) {
+		f, _ := NewFactory(
+			WithGenerator(&DefaultGenerator{Clock: clock}),
+			WithForbiddenPrefixes([][]byte{timestampBytes}),
+		)
+
+		if _, err := f.New(); err == nil {
+			t.Fatalf("New() error = %v, wantErr true", err)
+		}
+	})
+
+	t.Run("Invalid prefix", func(t *testing.T) {
+		for _, prefix := range [][]byte{{}, make([]byte, 17)} {
+			if _, err := NewFactory(WithForbiddenPrefixes([][]byte{prefix})); err == nil {
+				t.Fatalf("NewFactory() error = %v, wantErr true", err)
+			}
+		}
+	})
+}
+
+func TestWithStrictTimestamp(t *testing.T) {
+	tests := []struct {
+		name      string
+		timestamp uint64
+		wantErr   bool
+	}{
+		{"Largest 48-bit timestamp", 1<<48 - 1, false},
+		{"Overflowing timestamp", 1 << 48, true},
+		{"64-bit timestamp", 1<<64 - 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &MockGenerator{
+				GenerateUnixTimestampMSFunc: func() uint64 {
+					return tt.timestamp
+				},
+			}
+
+			f, _ := NewFactory(WithGenerator(m), WithStrictTimestamp())
+
+			ldid, err := f.New()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr {
+				if timestamp, _ := ldid.Timestamp(); timestamp != tt.timestamp {
+					t.Fatalf("Timestamp() = %v, want %v", timestamp, tt.timestamp)
+				}
+			}
+		})
+	}
+
+	t.Run("Truncated without option", func(t *testing.T) {
+		m := &MockGenerator{
+			GenerateUnixTimestampMSFunc: func() uint64 {
+				return 1<<48 + 5
+			},
+		}
+
+		f, _ := NewFactory(WithGenerator(m))
+
+		ldid, err := f.New()
+		if err != nil {
+			t.Fatalf("New() error = %v, wantErr %v", err, false)
+		}
+
+		if timestamp, _ := ldid.Timestamp(); timestamp != 5 {
+			t.Fatalf("Timestamp() = %v, want %v", timestamp, 5)
+		}
+	})
+}
+
+func TestWithEnvironmentBit(t *testing.T) {
+	for _, set := range []bool{false, true} {
+		t.Run(fmt.Sprintf("Bit %v", set), func(t *testing.T) {
+			f, err := NewFactory(WithEnvironmentBit(set))
+			if err != nil {
+				t.Fatalf("NewFactory() error = %v, wantErr %v", err, false)
+			}
+
+			// Repeat to cover random data with either value in the leftmost bit
+			for i := 0; i < 32; i++ {
+				ldid, err := f.New()
+				if err != nil {
+					t.Fatalf("New() error = %v, wantErr %v", err, false)
+				}
+
+				bit, err := ldid.EnvironmentBit()
+				if err != nil {
+					t.Fatalf("EnvironmentBit() error = %v, wantErr %v", err, false)
+				}
+
+				if bit != set {
+					t.Fatalf("EnvironmentBit() = %v, want %v", bit, set)
+				}
+
+				if err := ldid.validate(); err != nil {
+					t.Fatalf("New() = %v, want valid LDID: %v", ldid, err)
+				}
+			}
+		})
+	}
+
+	t.Run("Keeps remaining random bits", func(t *testing.T) {
+		m := &MockGenerator{
+			GenerateRandomBitsFunc: func(randReader io.Reader, n int64) (uint64, error) {
+				return 1<<uint64(n) - 1, nil
+			},
+		}
+
+		f, _ := NewFactory(WithGenerator(m), WithEnvironmentBit(false))
+		ldid, _ := f.New()
+
+		if randB, _ := ldid.RandB(); randB != 1<<61-1 {
+			t.Fatalf("RandB() = %#x, want %#x", randB, uint64(1<<61-1))
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, err := (*LDID)(nil).EnvironmentBit(); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("EnvironmentBit() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestWithMaxDrift(t *testing.T) {
+	start := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		jump    time.Duration
+		wantErr bool
+	}{
+		{"Within threshold", 10 * time.Second, false},
+		{"Large forward jump", time.Hour, true},
+		{"Large backward jump", -time.Hour, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := start
+			g := &DefaultGenerator{
+				Clock: func() time.Time {
+					return now
+				},
+			}
+
+			f, err := NewFactory(WithGenerator(g), WithMaxDrift(time.Minute))
+			if err != nil {
+				t.Fatalf("NewFactory() error = %v, wantErr %v", err, false)
+			}
+
+			if _, err := f.New(); err != nil {
+				t.Fatalf("New() first call error = %v, wantErr %v", err, false)
+			}
+
+			now = now.Add(tt.jump)
+
+			if _, err := f.New(); (err != nil) != tt.wantErr {
+				t.Fatalf("New() after %v error = %v, wantErr %v", tt.jump, err, tt.wantErr)
+			}
+
+			// Returning the clock to the baseline resumes generation
+			now = start
+
+			if _, err := f.New(); err != nil {
+				t.Fatalf("New() after clock returned error = %v, wantErr %v", err, false)
+			}
+		})
+	}
+
+	t.Run("Invalid threshold", func(t *testing.T) {
+		if _, err := NewFactory(WithMaxDrift(0)); err == nil {
+			t.Fatalf("NewFactory() error = %v, wantErr true", err)
+		}
+	})
+}
+
+func TestWithCounterFallback(t *testing.T) {
+	g := &DefaultGenerator{
+		Clock: func() time.Time {
+			return time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+		},
+		Reader: zeroReader{},
+	}
+
+	t.Run("Zero entropy", func(t *testing.T) {
+		f, err := NewFactory(WithGenerator(g), WithCounterFallback())
+		if err != nil {
+			t.Fatalf("NewFactory() error = %v, wantErr %v", err, false)
+		}
+
+		seen := make(map[string]bool)
+		for i := 0; i < 1000; i++ {
+			ldid, err := f.New()
+			if err != nil {
+				t.Fatalf("New() error = %v, wantErr %v", err, false)
+			}
+
+			if seen[ldid.String()] {
+				t.Fatalf("New() = %v, want unique LDIDs", ldid)
+			}
+			seen[ldid.String()] = true
+
+			if !ldid.IsValid() {
+				t.Fatalf("New() = %v, want valid LDID", ldid)
+			}
+		}
+	})
+
+	t.Run("Without option", func(t *testing.T) {
+		f, _ := NewFactory(WithGenerator(g))
+
+		a, _ := f.New()
+		b, _ := f.New()
+
+		if a.String() != b.String() {
+			t.Fatalf("New() = %v and %v, want equal LDIDs with zero entropy", a, b)
+		}
+	})
+
+	t.Run("Combined with MAC and environment bit", func(t *testing.T) {
+		key := []byte("secret key")
+		f, _ := NewFactory(WithGenerator(g), WithCounterFallback(), WithMAC(key), WithEnvironmentBit(true))
+
+		a, _ := f.New()
+		b, _ := f.New()
+
+		if a.String() == b.String() {
+			t.Fatalf("New() = %v twice, want unique LDIDs", a)
+		}
+
+		if valid, _ := b.VerifyMAC(key); !valid {
+			t.Fatalf("VerifyMAC() = %v, want %v", valid, true)
+		}
+
+		if bit, _ := b.EnvironmentBit(); !bit {
+			t.Fatalf("EnvironmentBit() = %v, want %v", bit, true)
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package id
+
+// Lengths of the supported LDID representations.
+const (
+	ByteLength      = 16 // Length of the raw bytes.
+	CanonicalLength = 36 // Length of the canonical hyphenated hex string.
+	HexLength       = 32 // Length of the hex string without hyphens.
+	Base32Length    = 26 // Length of the unpadded base32 string.
+	Base64Length    = 22 // Length of the unpadded base64 string.
+	DecimalLength   = 39 // Length of the zero-padded decimal string.
+)
+
+// Format identifies a representation of an LDID.
+type Format int
+
+// Supported representations of an LDID.
+const (
+	FormatCanonical Format = iota // Canonical hyphenated hex, e.g. 018cc251-f400-7000-8000-000000000000.
+	FormatHex                     // Hex without hyphens.
+	FormatBytes                   // Raw bytes.
+	FormatBase32                  // Unpadded base32.
+	FormatBase64                  // Unpadded URL-safe base64.
+)
+
+// ExpectedLength returns the length of an LDID in the given format, or 0 for an unknown format.
+func ExpectedLength(f Format) int {
+	switch f {
+	case FormatCanonical:
+		return CanonicalLength
+	case FormatHex:
+		return HexLength
+	case FormatBytes:
+		return ByteLength
+	case FormatBase32:
+		return Base32Length
+	case FormatBase64:
+		return Base64Length
+	default:
+		return 0
+	}
+}
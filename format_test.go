@@ -0,0 +1,41 @@
+package id
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+)
+
+func TestExpectedLength(t *testing.T) {
+	ldid, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v, wantErr %v", err, false)
+	}
+
+	tests := []struct {
+		name   string
+		format Format
+		output string
+	}{
+		{"Canonical", FormatCanonical, ldid.String()},
+		{"Hex", FormatHex, hex.EncodeToString(ldid.Bytes())},
+		{"Bytes", FormatBytes, string(ldid.Bytes())},
+		{"Base32", FormatBase32, base32.HexEncoding.WithPadding(base32.NoPadding).EncodeToString(ldid.Bytes())},
+		{"Base64", FormatBase64, base64.RawURLEncoding.EncodeToString(ldid.Bytes())},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if length := ExpectedLength(tt.format); length != len(tt.output) {
+				t.Fatalf("ExpectedLength() = %v, want %v", length, len(tt.output))
+			}
+		})
+	}
+
+	t.Run("Unknown format", func(t *testing.T) {
+		if length := ExpectedLength(Format(-1)); length != 0 {
+			t.Fatalf("ExpectedLength() = %v, want %v", length, 0)
+		}
+	})
+}
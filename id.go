@@ -2,13 +2,16 @@ package id
 
 import (
 	"crypto/rand"
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
-	"math/big"
 	"strings"
+	"sync/atomic"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"go.loafoe.dev/bitfield/v2"
 )
@@ -29,72 +32,90 @@ const (
 
 type LDID struct {
 	bf *bitfield.BitField
+	// str caches the canonical string representation computed by String.
+	str atomic.Pointer[string]
 }
 
+// ErrNilLDID is returned when a method is called on a nil or uninitialized LDID.
+var ErrNilLDID = errors.New("nil LDID")
+
 type Generator interface {
 	GenerateUnixTimestampMS() uint64
 	GenerateRandomBits(randReader io.Reader, n int64) (uint64, error)
 }
 
-type DefaultGenerator struct{}
+type DefaultGenerator struct {
+	// Clock returns the current time. When nil, time.Now is used.
+	Clock func() time.Time
+	// Reader is the source of random data. When non-nil, it is used instead of the reader
+	// passed to GenerateRandomBits and GenerateRandomBytes, which is crypto/rand.Reader.
+	Reader io.Reader
+}
 
 var defaultGenerator Generator = &DefaultGenerator{}
 
 func (g *DefaultGenerator) GenerateUnixTimestampMS() uint64 {
+	// g may be nil when DefaultGenerator is embedded as a pointer
+	if g != nil && g.Clock != nil {
+		return uint64(g.Clock().UnixMilli())
+	}
 	return uint64(time.Now().UnixMilli())
 }
 
-func (g *DefaultGenerator) GenerateRandomBits(randReader io.Reader, n int64) (r uint64, err error) {
-	// rand.Int can panic if n <= 0, so we need to recover from that
-	defer func() {
-		if r := recover(); r != nil {
-			err = fmt.Errorf("crypto/rand: %v", r)
-		}
-	}()
-
-	max := &big.Int{}
-	max.Exp(big.NewInt(2), big.NewInt(n), nil).Sub(max, big.NewInt(1)) // 2^n-1
+// reader returns the configured Reader, or randReader when none is configured.
+func (g *DefaultGenerator) reader(randReader io.Reader) io.Reader {
+	// g may be nil when DefaultGenerator is embedded as a pointer
+	if g != nil && g.Reader != nil {
+		return g.Reader
+	}
+	return randReader
+}
 
-	if !max.IsUint64() {
-		return 0, errors.New("failed to generate random bits: n is too large to fit in a uint64")
+// GenerateRandomBits returns n random bits, for n between 1 and 64. It reads exactly the
+// ceil(n/8) bytes needed from the reader and discards the excess high bits, so an LDID consumes
+// 10 bytes: 2 for the 12 bits of RandA and 8 for the 62 bits of RandB. This keeps the use of
+// constrained entropy sources to a minimum.
+func (g *DefaultGenerator) GenerateRandomBits(randReader io.Reader, n int64) (uint64, error) {
+	if n <= 0 || n > 64 {
+		return 0, fmt.Errorf("failed to generate random bits: n must be between 1 and 64, got %d", n)
 	}
 
-	rb, err := rand.Int(randReader, max)
-	if err != nil {
+	var b [8]byte
+	size := (n + 7) / 8
+
+	if _, err := io.ReadFull(g.reader(randReader), b[8-size:]); err != nil {
 		return 0, fmt.Errorf("failed to generate random bits: %w", err)
 	}
 
-	return rb.Uint64(), nil
+	v := binary.BigEndian.Uint64(b[:])
+	if n < 64 {
+		v &= 1<<uint64(n) - 1
+	}
+
+	return v, nil
 }
 
-// NewWithGenerator creates a new LDID with a provided generator
+// NewWithGenerator creates a new LDID with a provided generator. Generated values wider than
+// their field are truncated to the field size, so a timestamp beyond 48 bits silently wraps;
+// use a Factory with WithStrictTimestamp to get an error instead.
 func NewWithGenerator(g Generator) (*LDID, error) {
-	var id = &LDID{
-		bf: bitfield.BigEndian.New(128),
-	}
-
 	// Unix Timestamp (48 bits, 0-47)
-	timestamp := g.GenerateUnixTimestampMS()
-	// Version (4 bits, 48-51)
-	version := uint64(0b0111)
+	timestamp, err := generateTimestamp(g)
+	if err != nil {
+		return &LDID{}, err
+	}
 	// Pseudo-random data A (12 bits, 52-63)
 	randA, err := g.GenerateRandomBits(rand.Reader, 12)
 	if err != nil {
 		return &LDID{}, err
 	}
-	// Variant (2 bits, 64-65)
-	variant := uint64(0b10)
 	// Pseudo-random data B (62 bits, 66-127)
 	randB, err := g.GenerateRandomBits(rand.Reader, 62)
 	if err != nil {
 		return &LDID{}, err
 	}
 
-	id.bf.InsertUint64(timestampOffset, timestampSize, timestamp)
-	id.bf.InsertUint64(versionOffset, versionSize, version)
-	id.bf.InsertUint64(randAOffset, randASize, randA)
-	id.bf.InsertUint64(variantOffset, variantSize, variant)
-	id.bf.InsertUint64(randBOffset, randBSize, randB)
+	id := newFromFields(timestamp, randA, saltRandB(g, randB))
 
 	if err := id.bf.Error(); err != nil {
 		return &LDID{}, err
@@ -103,6 +124,27 @@ func NewWithGenerator(g Generator) (*LDID, error) {
 	return id, nil
 }
 
+// newFromFields creates a new LDID from its timestamp and random fields, setting the
+// version and variant bits. Values wider than their field are truncated to the field size.
+func newFromFields(timestamp, randA, randB uint64) *LDID {
+	var id = &LDID{
+		bf: bitfield.BigEndian.New(128),
+	}
+
+	// Version (4 bits, 48-51)
+	version := uint64(0b0111)
+	// Variant (2 bits, 64-65)
+	variant := uint64(0b10)
+
+	id.bf.InsertUint64(timestampOffset, timestampSize, timestamp)
+	id.bf.InsertUint64(versionOffset, versionSize, version)
+	id.bf.InsertUint64(randAOffset, randASize, randA)
+	id.bf.InsertUint64(variantOffset, variantSize, variant)
+	id.bf.InsertUint64(randBOffset, randBSize, randB)
+
+	return id
+}
+
 // New creates a new LDID with the default generator
 func New() (*LDID, error) {
 	// Use the default when creating a new LDID
@@ -124,8 +166,9 @@ func parseUUIDString(s string) ([]byte, error) {
 }
 
 // FromString parses the canonical string representation of a UUID into a new LDID.
+// Leading and trailing whitespace is ignored.
 func FromString(s string) (*LDID, error) {
-	bytes, err := parseUUIDString(s)
+	bytes, err := parseUUIDString(strings.TrimSpace(s))
 	if err != nil {
 		return &LDID{}, err
 	}
@@ -139,34 +182,160 @@ func FromString(s string) (*LDID, error) {
 	return ldid, nil
 }
 
+// FromStringStrict parses s like FromString, but rejects any character other than hex digits
+// and hyphens after trimming leading and trailing whitespace, including internal whitespace
+// and invalid UTF-8. The error names the offending rune and its byte position in s.
+func FromStringStrict(s string) (*LDID, error) {
+	trimmed := strings.TrimLeftFunc(s, unicode.IsSpace)
+	start := len(s) - len(trimmed)
+	trimmed = strings.TrimRightFunc(trimmed, unicode.IsSpace)
+
+	for i := 0; i < len(trimmed); {
+		r, size := utf8.DecodeRuneInString(trimmed[i:])
+
+		// A correctly encoded U+FFFD is an invalid character, not an invalid byte
+		if r == utf8.RuneError && size == 1 {
+			return &LDID{}, fmt.Errorf("failed to parse LDID: invalid UTF-8 byte %#x at position %d", trimmed[i], start+i)
+		}
+
+		if r != '-' && !isHexDigit(r) {
+			return &LDID{}, fmt.Errorf("failed to parse LDID: invalid character %q at position %d", r, start+i)
+		}
+
+		i += size
+	}
+
+	return FromString(trimmed)
+}
+
+// isHexDigit reports whether r is a hexadecimal digit.
+func isHexDigit(r rune) bool {
+	return '0' <= r && r <= '9' || 'a' <= r && r <= 'f' || 'A' <= r && r <= 'F'
+}
+
+// fromBytes creates a new LDID from exactly 16 raw bytes.
+func fromBytes(b []byte) *LDID {
+	bf := bitfield.BigEndian.New(128)
+	bf.InsertUint64(0, 64, binary.BigEndian.Uint64(b[0:8]))
+	bf.InsertUint64(64, 64, binary.BigEndian.Uint64(b[8:16]))
+
+	return &LDID{
+		bf: bf,
+	}
+}
+
+// Assemble creates a new LDID from the 6 timestamp bytes and the 10 bytes that follow them,
+// as stored separately by columnar layouts. The version and variant bits are overwritten
+// with version 7 and the RFC variant.
+func Assemble(timeBytes []byte, entropyBytes []byte) (*LDID, error) {
+	if len(timeBytes) != 6 {
+		return &LDID{}, fmt.Errorf("failed to assemble LDID: got %d time bytes, want 6", len(timeBytes))
+	}
+
+	if len(entropyBytes) != 10 {
+		return &LDID{}, fmt.Errorf("failed to assemble LDID: got %d entropy bytes, want 10", len(entropyBytes))
+	}
+
+	b := make([]byte, 0, 16)
+	b = append(b, timeBytes...)
+	b = append(b, entropyBytes...)
+
+	id := fromBytes(b)
+	id.bf.InsertUint64(versionOffset, versionSize, 0b0111)
+	id.bf.InsertUint64(variantOffset, variantSize, 0b10)
+
+	if err := id.bf.Error(); err != nil {
+		return &LDID{}, err
+	}
+
+	return id, nil
+}
+
+// isNil reports whether the LDID is nil or has no underlying bitfield.
+func (id *LDID) isNil() bool {
+	return id == nil || id.bf == nil
+}
+
+// set replaces the underlying bitfield of the LDID and invalidates the cached string.
+func (id *LDID) set(bf *bitfield.BitField) {
+	id.bf = bf
+	id.str.Store(nil)
+}
+
 // String formats the LDID bytes into the canonical string representation of a UUID.
+// It returns an empty string for a nil LDID.
+//
+// The string is computed once and cached on the LDID, which is safe for concurrent use.
+// LDIDs are not modified after creation; methods that replace the contents of an existing
+// LDID, such as UnmarshalJSON, invalidate the cache.
 func (id *LDID) String() string {
-	bytes := id.bf.Bytes()
-	return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x",
-		bytes[0:4], bytes[4:6], bytes[6:8], bytes[8:10], bytes[10:])
+	if id.isNil() {
+		return ""
+	}
+
+	if s := id.str.Load(); s != nil {
+		return *s
+	}
+
+	s := string(id.AppendText(make([]byte, 0, CanonicalLength)))
+	id.str.Store(&s)
+
+	return s
 }
 
-// Bytes returns the raw bytes of the LDID.
+// Bytes returns the raw bytes of the LDID, or nil for a nil LDID.
 func (id *LDID) Bytes() []byte {
+	if id.isNil() {
+		return nil
+	}
+
 	return id.bf.Bytes()
 }
 
+// Timestamp returns the Unix timestamp in milliseconds, or ErrNilLDID for a nil LDID.
 func (id *LDID) Timestamp() (uint64, error) {
-	return id.bf.ExtractUint64(timestampOffset, timestampSize)
+	return id.extract(timestampOffset, timestampSize)
 }
 
+// Version returns the version field, or ErrNilLDID for a nil LDID.
 func (id *LDID) Version() (uint64, error) {
-	return id.bf.ExtractUint64(versionOffset, versionSize)
+	return id.extract(versionOffset, versionSize)
 }
 
+// RandA returns the random data A field, or ErrNilLDID for a nil LDID.
 func (id *LDID) RandA() (uint64, error) {
-	return id.bf.ExtractUint64(randAOffset, randASize)
+	return id.extract(randAOffset, randASize)
 }
 
+// Variant returns the variant field, or ErrNilLDID for a nil LDID. The variant field is
+// variable width (RFC 9562 section 4.1), so only its significant bits are returned: 0b0 for
+// NCS, 0b10 for RFC 9562, 0b110 for Microsoft and 0b111 for the reserved future variant.
 func (id *LDID) Variant() (uint64, error) {
-	return id.bf.ExtractUint64(variantOffset, variantSize)
+	bits, err := id.extract(variantOffset, 3)
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case bits>>2 == 0b0:
+		return 0b0, nil
+	case bits>>1 == 0b10:
+		return 0b10, nil
+	default:
+		return bits, nil
+	}
 }
 
+// RandB returns the random data B field, or ErrNilLDID for a nil LDID.
 func (id *LDID) RandB() (uint64, error) {
-	return id.bf.ExtractUint64(randBOffset, randBSize)
+	return id.extract(randBOffset, randBSize)
+}
+
+// extract reads size bits at offset from the underlying bitfield.
+func (id *LDID) extract(offset, size uint64) (uint64, error) {
+	if id.isNil() {
+		return 0, ErrNilLDID
+	}
+
+	return id.bf.ExtractUint64(offset, size)
 }
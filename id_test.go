@@ -1,11 +1,15 @@
 package id
 
 import (
+	"bytes"
 	"crypto/rand"
 	"errors"
+	"fmt"
 	"io"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 )
 
 // Mocks
@@ -72,6 +76,144 @@ func TestGenerateRandomBits(t *testing.T) {
 			t.Fatalf("GenerateRandomBits() error = %v, wantErr true", err)
 		}
 	})
+
+	t.Run("Reads minimal bytes", func(t *testing.T) {
+		tests := []struct {
+			n         int64
+			wantBytes int
+		}{
+			{1, 1},
+			{8, 1},
+			{12, 2},
+			{62, 8},
+			{64, 8},
+		}
+
+		for _, tt := range tests {
+			r := &countingReader{r: rand.Reader}
+
+			v, err := defaultGenerator.GenerateRandomBits(r, tt.n)
+			if err != nil {
+				t.Fatalf("GenerateRandomBits(%v) error = %v, wantErr %v", tt.n, err, false)
+			}
+
+			if r.n != tt.wantBytes {
+				t.Fatalf("GenerateRandomBits(%v) read %v bytes, want %v", tt.n, r.n, tt.wantBytes)
+			}
+
+			if tt.n < 64 && v >= 1<<uint64(tt.n) {
+				t.Fatalf("GenerateRandomBits(%v) = %v, want less than 2^%v", tt.n, v, tt.n)
+			}
+		}
+	})
+
+	t.Run("Masks excess bits", func(t *testing.T) {
+		v, err := defaultGenerator.GenerateRandomBits(bytes.NewReader([]byte{0xff, 0xff}), 12)
+		if err != nil {
+			t.Fatalf("GenerateRandomBits() error = %v, wantErr %v", err, false)
+		}
+
+		if v != 0xfff {
+			t.Fatalf("GenerateRandomBits() = %#x, want %#x", v, 0xfff)
+		}
+	})
+
+	t.Run("Bytes per New", func(t *testing.T) {
+		r := &countingReader{r: rand.Reader}
+
+		if _, err := NewWithGenerator(&DefaultGenerator{Reader: r}); err != nil {
+			t.Fatalf("NewWithGenerator() error = %v, wantErr %v", err, false)
+		}
+
+		if r.n != randomBytesPerLDID {
+			t.Fatalf("NewWithGenerator() read %v bytes, want %v", r.n, randomBytesPerLDID)
+		}
+	})
+}
+
+func TestGenerateUnixTimestampMS(t *testing.T) {
+	t.Run("Frozen clock", func(t *testing.T) {
+		frozen := time.Date(2024, time.January, 2, 3, 4, 5, 6000000, time.UTC)
+		expectedTimestamp := uint64(frozen.UnixMilli())
+
+		g := &DefaultGenerator{
+			Clock: func() time.Time {
+				return frozen
+			},
+		}
+
+		ldid, err := NewWithGenerator(g)
+
+		if err != nil {
+			t.Fatalf("NewWithGenerator() error = %v, wantErr %v", err, false)
+		}
+
+		if timestamp, _ := ldid.Timestamp(); timestamp != expectedTimestamp {
+			t.Fatalf("Timestamp() = %v, want %v", timestamp, expectedTimestamp)
+		}
+	})
+
+	t.Run("Nil clock", func(t *testing.T) {
+		before := uint64(time.Now().UnixMilli())
+		timestamp := (&DefaultGenerator{}).GenerateUnixTimestampMS()
+		after := uint64(time.Now().UnixMilli())
+
+		if timestamp < before || timestamp > after {
+			t.Fatalf("GenerateUnixTimestampMS() = %v, want between %v and %v", timestamp, before, after)
+		}
+	})
+}
+
+// zeroReader is a deterministic reader that only produces zero bytes.
+type zeroReader struct{}
+
+func (zeroReader) Read(b []byte) (int, error) {
+	for i := range b {
+		b[i] = 0
+	}
+	return len(b), nil
+}
+
+func TestDefaultGeneratorReader(t *testing.T) {
+	t.Run("Configured reader", func(t *testing.T) {
+		g := &DefaultGenerator{Reader: zeroReader{}}
+
+		ldid, err := NewWithGenerator(g)
+		if err != nil {
+			t.Fatalf("NewWithGenerator() error = %v, wantErr %v", err, false)
+		}
+
+		if randA, _ := ldid.RandA(); randA != 0 {
+			t.Fatalf("RandA() = %v, want %v", randA, 0)
+		}
+
+		if randB, _ := ldid.RandB(); randB != 0 {
+			t.Fatalf("RandB() = %v, want %v", randB, 0)
+		}
+	})
+
+	t.Run("Configured reader in batch", func(t *testing.T) {
+		g := &DefaultGenerator{Reader: zeroReader{}}
+
+		ids, err := NewBatchWithGenerator(g, 2)
+		if err != nil {
+			t.Fatalf("NewBatchWithGenerator() error = %v, wantErr %v", err, false)
+		}
+
+		for _, ldid := range ids {
+			if randB, _ := ldid.RandB(); randB != 0 {
+				t.Fatalf("RandB() = %v, want %v", randB, 0)
+			}
+		}
+	})
+
+	t.Run("Configured reader failing", func(t *testing.T) {
+		g := &DefaultGenerator{Reader: &MockRandomReader{}}
+
+		if _, err := NewWithGenerator(g); err == nil {
+			t.Fatalf("NewWithGenerator() error = %v, wantErr true", err)
+		}
+	})
 }
 
 func TestNewWithGenerator(t *testing.T) {
@@ -214,3 +356,288 @@ func TestString(t *testing.T) {
 		t.Fatalf("String() = %v, want a valid UUID-like string", str)
 	}
 }
+
+func TestFromString(t *testing.T) {
+	const want = "018cc251-f400-7abc-8def-0123456789ab"
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"Canonical", want, false},
+		{"Leading spaces", "  " + want, false},
+		{"Surrounding whitespace", "\t" + want + "\r\n", false},
+		{"Embedded newline", want[:8] + "\n" + want[8:], true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ldid, err := FromString(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FromString() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && ldid.String() != want {
+				t.Fatalf("FromString() = %v, want %v", ldid, want)
+			}
+		})
+	}
+}
+
+func TestFromStringStrict(t *testing.T) {
+	const want = "018cc251-f400-7abc-8def-0123456789ab"
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"Canonical", want, ""},
+		{"Leading spaces", "  " + want, ""},
+		{"Embedded newline", "  " + want[:8] + "\n" + want[8:], `invalid character '\n' at position 10`},
+		{"Embedded space", want[:13] + " " + want[13:], `invalid character ' ' at position 13`},
+		{"Non-hex character", "g" + want[1:], `invalid character 'g' at position 0`},
+		{"Invalid UTF-8", want[:4] + "\xff" + want[4:], `invalid UTF-8 byte 0xff at position 4`},
+		{"Replacement character", want[:4] + "\uFFFD" + want[4:], "invalid character '\uFFFD' at position 4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ldid, err := FromStringStrict(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("FromStringStrict() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("FromStringStrict() error = %v, wantErr %v", err, false)
+			}
+
+			if ldid.String() != want {
+				t.Fatalf("FromStringStrict() = %v, want %v", ldid, want)
+			}
+		})
+	}
+}
+
+func TestRFC9562ByteLayout(t *testing.T) {
+	// Test vector from RFC 9562 appendix A.6, which is also the layout produced by
+	// PostgreSQL's uuidv7(): the big-endian timestamp occupies bytes 0-5, so byte-wise
+	// comparison orders by time.
+	g := &MockGenerator{
+		GenerateUnixTimestampMSFunc: func() uint64 {
+			return 0x017F22E279B0
+		},
+		GenerateRandomBitsFunc: func(randReader io.Reader, n int64) (uint64, error) {
+			if n == 12 {
+				return 0xCC3, nil
+			}
+			return 0x18C4DC0C0C07398F, nil
+		},
+	}
+
+	ldid, err := NewWithGenerator(g)
+	if err != nil {
+		t.Fatalf("NewWithGenerator() error = %v, wantErr %v", err, false)
+	}
+
+	want := []byte{
+		0x01, 0x7F, 0x22, 0xE2, 0x79, 0xB0, // unix_ts_ms
+		0x7C, 0xC3, // ver and rand_a
+		0x98, 0xC4, 0xDC, 0x0C, 0x0C, 0x07, 0x39, 0x8F, // var and rand_b
+	}
+
+	if got := ldid.Bytes(); !bytes.Equal(got, want) {
+		t.Fatalf("Bytes() = %x, want %x", got, want)
+	}
+
+	if got := ldid.String(); got != "017f22e2-79b0-7cc3-98c4-dc0c0c07398f" {
+		t.Fatalf("String() = %v, want %v", got, "017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	}
+
+	t.Run("Byte order follows time", func(t *testing.T) {
+		earlier := TestID(time.UnixMilli(0x017F22E279AF), 0xFFFF)
+
+		if bytes.Compare(earlier.Bytes(), ldid.Bytes()) >= 0 {
+			t.Fatalf("bytes.Compare(%v, %v) >= 0, want < 0", earlier, ldid)
+		}
+	})
+}
+
+func TestNilLDID(t *testing.T) {
+	var ldid *LDID
+
+	accessors := map[string]func() (uint64, error){
+		"Timestamp": ldid.Timestamp,
+		"Version":   ldid.Version,
+		"RandA":     ldid.RandA,
+		"Variant":   ldid.Variant,
+		"RandB":     ldid.RandB,
+	}
+
+	for name, accessor := range accessors {
+		t.Run(name, func(t *testing.T) {
+			if _, err := accessor(); !errors.Is(err, ErrNilLDID) {
+				t.Fatalf("%s() error = %v, want %v", name, err, ErrNilLDID)
+			}
+		})
+	}
+
+	t.Run("String", func(t *testing.T) {
+		if str := ldid.String(); str != "" {
+			t.Fatalf("String() = %v, want empty string", str)
+		}
+	})
+
+	t.Run("Bytes", func(t *testing.T) {
+		if bytes := ldid.Bytes(); bytes != nil {
+			t.Fatalf("Bytes() = %v, want nil", bytes)
+		}
+	})
+
+	t.Run("Uninitialized", func(t *testing.T) {
+		if _, err := (&LDID{}).Timestamp(); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("Timestamp() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestAssemble(t *testing.T) {
+	t.Run("Round trip", func(t *testing.T) {
+		ldid, err := New()
+		if err != nil {
+			t.Fatalf("New() error = %v, wantErr %v", err, false)
+		}
+
+		b := ldid.Bytes()
+
+		assembled, err := Assemble(b[0:6], b[6:16])
+		if err != nil {
+			t.Fatalf("Assemble() error = %v, wantErr %v", err, false)
+		}
+
+		if assembled.String() != ldid.String() {
+			t.Fatalf("Assemble() = %v, want %v", assembled, ldid)
+		}
+	})
+
+	t.Run("Fixes version and variant", func(t *testing.T) {
+		assembled, err := Assemble(make([]byte, 6), make([]byte, 10))
+		if err != nil {
+			t.Fatalf("Assemble() error = %v, wantErr %v", err, false)
+		}
+
+		if version, _ := assembled.Version(); version != 0b0111 {
+			t.Fatalf("Version() = %v, want %v", version, 0b0111)
+		}
+
+		if variant, _ := assembled.Variant(); variant != 0b10 {
+			t.Fatalf("Variant() = %v, want %v", variant, 0b10)
+		}
+	})
+
+	t.Run("Invalid lengths", func(t *testing.T) {
+		if _, err := Assemble(make([]byte, 5), make([]byte, 10)); err == nil {
+			t.Fatalf("Assemble() error = %v, wantErr true", err)
+		}
+
+		if _, err := Assemble(make([]byte, 6), make([]byte, 11)); err == nil {
+			t.Fatalf("Assemble() error = %v, wantErr true", err)
+		}
+	})
+}
+
+// sprintfString is the original fmt based implementation of String, kept as a reference.
+func sprintfString(id *LDID) string {
+	bytes := id.Bytes()
+	return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x",
+		bytes[0:4], bytes[4:6], bytes[6:8], bytes[8:10], bytes[10:])
+}
+
+func TestStringMatchesSprintf(t *testing.T) {
+	ids, err := NewBatch(1000)
+	if err != nil {
+		t.Fatalf("NewBatch() error = %v, wantErr %v", err, false)
+	}
+
+	ids = append(ids, fromBytes(make([]byte, 16)), newFromFields(1, 0, 0))
+
+	for _, ldid := range ids {
+		if str, expected := ldid.String(), sprintfString(ldid); str != expected {
+			t.Fatalf("String() = %v, want %v", str, expected)
+		}
+	}
+}
+
+func TestStringCache(t *testing.T) {
+	t.Run("Cached", func(t *testing.T) {
+		ldid, _ := New()
+		expected := ldid.String()
+
+		allocs := testing.AllocsPerRun(100, func() {
+			_ = ldid.String()
+		})
+
+		if allocs != 0 {
+			t.Fatalf("String() allocs = %v, want %v", allocs, 0)
+		}
+
+		if str := ldid.String(); str != expected {
+			t.Fatalf("String() = %v, want %v", str, expected)
+		}
+	})
+
+	t.Run("Invalidated by UnmarshalJSON", func(t *testing.T) {
+		ldid, _ := New()
+		other, _ := New()
+		_ = ldid.String()
+
+		if err := ldid.UnmarshalJSON([]byte(`"` + other.String() + `"`)); err != nil {
+			t.Fatalf("UnmarshalJSON() error = %v, wantErr %v", err, false)
+		}
+
+		if str := ldid.String(); str != other.String() {
+			t.Fatalf("String() = %v, want %v", str, other)
+		}
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		ldid, _ := New()
+		expected := sprintfString(ldid)
+
+		done := make(chan string)
+		for i := 0; i < 10; i++ {
+			go func() {
+				done <- ldid.String()
+			}()
+		}
+
+		for i := 0; i < 10; i++ {
+			if str := <-done; str != expected {
+				t.Fatalf("String() = %v, want %v", str, expected)
+			}
+		}
+	})
+}
+
+func BenchmarkStringUncached(b *testing.B) {
+	ldid, _ := New()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ldid.str.Store(nil)
+		_ = ldid.String()
+	}
+}
+
+func BenchmarkStringSprintf(b *testing.B) {
+	ldid, _ := New()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = sprintfString(ldid)
+	}
+}
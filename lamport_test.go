@@ -0,0 +1,80 @@
+package id
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLamportGenerator(t *testing.T) {
+	t.Run("Ticks sort in issue order", func(t *testing.T) {
+		g := &LamportGenerator{}
+
+		var prev *LDID
+		for i := 1; i <= 1000; i++ {
+			ldid, err := g.Tick()
+			if err != nil {
+				t.Fatalf("Tick() error = %v, wantErr %v", err, false)
+			}
+
+			if clock, _ := ldid.Timestamp(); clock != uint64(i) {
+				t.Fatalf("Timestamp() = %v, want %v", clock, i)
+			}
+
+			if prev != nil {
+				if c, _ := Compare(prev, ldid); c != -1 {
+					t.Fatalf("Compare() = %v, want %v", c, -1)
+				}
+			}
+
+			prev = ldid
+		}
+	})
+
+	t.Run("Witness orders after received LDID", func(t *testing.T) {
+		sender, receiver := &LamportGenerator{}, &LamportGenerator{}
+
+		var sent *LDID
+		for i := 0; i < 10; i++ {
+			sent, _ = sender.Tick()
+		}
+
+		if err := receiver.Witness(sent); err != nil {
+			t.Fatalf("Witness() error = %v, wantErr %v", err, false)
+		}
+
+		received, _ := receiver.Tick()
+		if c, _ := Compare(sent, received); c != -1 {
+			t.Fatalf("Compare() = %v, want %v", c, -1)
+		}
+
+		// Witnessing an older LDID does not move the clock back
+		older := newFromFields(1, 0, 0)
+		if err := receiver.Witness(older); err != nil {
+			t.Fatalf("Witness() error = %v, wantErr %v", err, false)
+		}
+
+		next, _ := receiver.Tick()
+		if clock, _ := next.Timestamp(); clock != 12 {
+			t.Fatalf("Timestamp() = %v, want %v", clock, 12)
+		}
+	})
+
+	t.Run("Clock overflow", func(t *testing.T) {
+		g := &LamportGenerator{}
+		g.Witness(newFromFields(1<<timestampSize-1, 0, 0))
+
+		if _, err := g.Tick(); err == nil {
+			t.Fatalf("Tick() error = %v, wantErr %v", err, true)
+		}
+
+		if clock := g.GenerateUnixTimestampMS(); clock != 0 {
+			t.Fatalf("GenerateUnixTimestampMS() = %v, want %v", clock, 0)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if err := (&LamportGenerator{}).Witness(nil); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("Witness() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package id
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"go.loafoe.dev/bitfield/v2"
+)
+
+// Layout describes the sizes of the timestamp and random fields of an LDID. The fields keep
+// their order, with the 4 bit version after the timestamp and the 2 bit variant after RandA:
+//
+//	timestamp | version | RandA | variant | RandB
+//
+// The sizes of the timestamp, RandA and RandB fields must add up to 122 bits. Layouts other
+// than DefaultLayout do not produce RFC 9562 compliant UUIDs, and LDIDs created with them must
+// be read with the accessors of the same Layout.
+type Layout struct {
+	TimestampSize uint64 // Size of the timestamp field in bits.
+	RandASize     uint64 // Size of the random data A field in bits.
+	RandBSize     uint64 // Size of the random data B field in bits.
+}
+
+// DefaultLayout is the RFC 9562 UUIDv7 layout used by New and NewWithGenerator.
+var DefaultLayout = Layout{
+	TimestampSize: timestampSize,
+	RandASize:     randASize,
+	RandBSize:     randBSize,
+}
+
+// Validate returns an error if the field sizes are not between 1 and 64 bits or do not add up
+// to 122 bits.
+func (l Layout) Validate() error {
+	for name, size := range map[string]uint64{
+		"timestamp": l.TimestampSize,
+		"RandA":     l.RandASize,
+		"RandB":     l.RandBSize,
+	} {
+		if size < 1 || size > 64 {
+			return fmt.Errorf("invalid layout: %s size %d must be between 1 and 64 bits", name, size)
+		}
+	}
+
+	if sum := l.TimestampSize + l.RandASize + l.RandBSize; sum != 128-versionSize-variantSize {
+		return fmt.Errorf("invalid layout: field sizes add up to %d bits, want %d", sum, 128-versionSize-variantSize)
+	}
+
+	return nil
+}
+
+func (l Layout) versionOffset() uint64 {
+	return l.TimestampSize
+}
+
+func (l Layout) randAOffset() uint64 {
+	return l.versionOffset() + versionSize
+}
+
+func (l Layout) variantOffset() uint64 {
+	return l.randAOffset() + l.RandASize
+}
+
+func (l Layout) randBOffset() uint64 {
+	return l.variantOffset() + variantSize
+}
+
+// NewWithLayout creates a new LDID with a provided generator and layout. Values returned by
+// the generator are truncated to the size of their field.
+func NewWithLayout(g Generator, l Layout) (*LDID, error) {
+	if err := l.Validate(); err != nil {
+		return &LDID{}, err
+	}
+
+	timestamp, err := generateTimestamp(g)
+	if err != nil {
+		return &LDID{}, err
+	}
+	randA, err := g.GenerateRandomBits(rand.Reader, int64(l.RandASize))
+	if err != nil {
+		return &LDID{}, err
+	}
+	randB, err := g.GenerateRandomBits(rand.Reader, int64(l.RandBSize))
+	if err != nil {
+		return &LDID{}, err
+	}
+
+	var id = &LDID{
+		bf: bitfield.BigEndian.New(128),
+	}
+
+	id.bf.InsertUint64(timestampOffset, l.TimestampSize, timestamp)
+	id.bf.InsertUint64(l.versionOffset(), versionSize, 0b0111)
+	id.bf.InsertUint64(l.randAOffset(), l.RandASize, randA)
+	id.bf.InsertUint64(l.variantOffset(), variantSize, 0b10)
+	id.bf.InsertUint64(l.randBOffset(), l.RandBSize, saltRandB(g, randB))
+
+	if err := id.bf.Error(); err != nil {
+		return &LDID{}, err
+	}
+
+	return id, nil
+}
+
+// Timestamp returns the timestamp field of an LDID created with this layout.
+func (l Layout) Timestamp(id *LDID) (uint64, error) {
+	return id.extract(timestampOffset, l.TimestampSize)
+}
+
+// Version returns the version field of an LDID created with this layout.
+func (l Layout) Version(id *LDID) (uint64, error) {
+	return id.extract(l.versionOffset(), versionSize)
+}
+
+// RandA returns the random data A field of an LDID created with this layout.
+func (l Layout) RandA(id *LDID) (uint64, error) {
+	return id.extract(l.randAOffset(), l.RandASize)
+}
+
+// Variant returns the variant field of an LDID created with this layout.
+func (l Layout) Variant(id *LDID) (uint64, error) {
+	return id.extract(l.variantOffset(), variantSize)
+}
+
+// RandB returns the random data B field of an LDID created with this layout.
+func (l Layout) RandB(id *LDID) (uint64, error) {
+	return id.extract(l.randBOffset(), l.RandBSize)
+}
@@ -0,0 +1,117 @@
+package id
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestLayoutValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		layout  Layout
+		wantErr bool
+	}{
+		{"Default", DefaultLayout, false},
+		{"32 bit timestamp", Layout{TimestampSize: 32, RandASize: 28, RandBSize: 62}, false},
+		{"Too few bits", Layout{TimestampSize: 32, RandASize: 12, RandBSize: 62}, true},
+		{"Too many bits", Layout{TimestampSize: 64, RandASize: 12, RandBSize: 62}, true},
+		{"Empty field", Layout{TimestampSize: 60, RandASize: 0, RandBSize: 62}, true},
+		{"Field wider than 64 bits", Layout{TimestampSize: 48, RandASize: 2, RandBSize: 72}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.layout.Validate(); (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewWithLayout(t *testing.T) {
+	t.Run("Custom layout round trip", func(t *testing.T) {
+		layout := Layout{TimestampSize: 32, RandASize: 28, RandBSize: 62}
+
+		expectedTimestamp := uint64(1704164645)
+		m := &MockGenerator{
+			GenerateUnixTimestampMSFunc: func() uint64 {
+				return expectedTimestamp
+			},
+			GenerateRandomBitsFunc: func(randReader io.Reader, n int64) (uint64, error) {
+				return uint64(n), nil
+			},
+		}
+
+		ldid, err := NewWithLayout(m, layout)
+		if err != nil {
+			t.Fatalf("NewWithLayout() error = %v, wantErr %v", err, false)
+		}
+
+		if timestamp, _ := layout.Timestamp(ldid); timestamp != expectedTimestamp {
+			t.Fatalf("Timestamp() = %v, want %v", timestamp, expectedTimestamp)
+		}
+
+		if version, _ := layout.Version(ldid); version != 0b0111 {
+			t.Fatalf("Version() = %v, want %v", version, 0b0111)
+		}
+
+		if randA, _ := layout.RandA(ldid); randA != 28 {
+			t.Fatalf("RandA() = %v, want %v", randA, 28)
+		}
+
+		if variant, _ := layout.Variant(ldid); variant != 0b10 {
+			t.Fatalf("Variant() = %v, want %v", variant, 0b10)
+		}
+
+		if randB, _ := layout.RandB(ldid); randB != 62 {
+			t.Fatalf("RandB() = %v, want %v", randB, 62)
+		}
+	})
+
+	t.Run("Default layout matches accessors", func(t *testing.T) {
+		ldid, err := NewWithLayout(defaultGenerator, DefaultLayout)
+		if err != nil {
+			t.Fatalf("NewWithLayout() error = %v, wantErr %v", err, false)
+		}
+
+		accessors := []struct {
+			name   string
+			layout func(*LDID) (uint64, error)
+			method func() (uint64, error)
+		}{
+			{"Timestamp", DefaultLayout.Timestamp, ldid.Timestamp},
+			{"Version", DefaultLayout.Version, ldid.Version},
+			{"RandA", DefaultLayout.RandA, ldid.RandA},
+			{"Variant", DefaultLayout.Variant, ldid.Variant},
+			{"RandB", DefaultLayout.RandB, ldid.RandB},
+		}
+
+		for _, a := range accessors {
+			got, _ := a.layout(ldid)
+			want, _ := a.method()
+
+			if got != want {
+				t.Fatalf("DefaultLayout.%s() = %v, want %v", a.name, got, want)
+			}
+		}
+	})
+
+	t.Run("Invalid layout", func(t *testing.T) {
+		if _, err := NewWithLayout(defaultGenerator, Layout{}); err == nil {
+			t.Fatalf("NewWithLayout() error = %v, wantErr true", err)
+		}
+	})
+
+	t.Run("GenerateRandomBits failing", func(t *testing.T) {
+		m := &MockGenerator{
+			GenerateRandomBitsFunc: func(randReader io.Reader, n int64) (uint64, error) {
+				return 0, errors.New("mock error")
+			},
+		}
+
+		if _, err := NewWithLayout(m, DefaultLayout); err == nil {
+			t.Fatalf("NewWithLayout() error = %v, wantErr true", err)
+		}
+	})
+}
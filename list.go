@@ -0,0 +1,100 @@
+package id
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// LDIDList is a list of LDIDs that encodes to a JSON array of canonical strings.
+//
+// A nil or empty list encodes as [] rather than null. Nil elements encode as null, and null
+// elements decode to nil LDIDs; callers that require every element to be present should check
+// for them.
+type LDIDList []*LDID
+
+// ParseList parses each string with Parse into a new LDIDList. It stops at the first string
+// that fails to parse and reports its index.
+func ParseList(strs []string) (LDIDList, error) {
+	l := make(LDIDList, len(strs))
+
+	for i, s := range strs {
+		id, err := Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse list at index %d: %w", i, err)
+		}
+		l[i] = id
+	}
+
+	return l, nil
+}
+
+// ParseStrings parses each string with Parse like ParseList, returning a plain slice. It stops
+// at the first string that fails to parse and reports its index.
+func ParseStrings(strs []string) ([]*LDID, error) {
+	return ParseList(strs)
+}
+
+// ParseStringsAll parses every string with Parse, without stopping at failures. It returns the
+// LDIDs and errors by index: for each string either the LDID or the error at its index is nil.
+// The error slice is nil if all strings parse.
+func ParseStringsAll(strs []string) ([]*LDID, []error) {
+	ids := make([]*LDID, len(strs))
+	var errs []error
+
+	for i, s := range strs {
+		id, err := Parse(s)
+		if err != nil {
+			if errs == nil {
+				errs = make([]error, len(strs))
+			}
+			errs[i] = err
+			continue
+		}
+		ids[i] = id
+	}
+
+	return ids, errs
+}
+
+// Strings returns the canonical string of each LDID, with an empty string for nil LDIDs.
+func Strings(ids []*LDID) []string {
+	strs := make([]string, len(ids))
+
+	for i, id := range ids {
+		strs[i] = id.String()
+	}
+
+	return strs
+}
+
+// ParseDelimited splits s on commas and whitespace and parses each token with Parse into a new
+// LDIDList, e.g. for a command line flag like --ids "a, b c". Empty tokens are skipped. It stops
+// at the first token that fails to parse and reports its index among the tokens and its value.
+func ParseDelimited(s string) (LDIDList, error) {
+	tokens := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+
+	l := make(LDIDList, len(tokens))
+
+	for i, token := range tokens {
+		id, err := Parse(token)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse list at index %d (%q): %w", i, token, err)
+		}
+		l[i] = id
+	}
+
+	return l, nil
+}
+
+// MarshalJSON encodes the list as a JSON array of canonical strings.
+func (l LDIDList) MarshalJSON() ([]byte, error) {
+	if l == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal([]*LDID(l))
+}
@@ -0,0 +1,230 @@
+package id
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestLDIDList(t *testing.T) {
+	t.Run("Round trip", func(t *testing.T) {
+		ids, err := NewBatch(3)
+		if err != nil {
+			t.Fatalf("NewBatch() error = %v, wantErr %v", err, false)
+		}
+
+		data, err := json.Marshal(LDIDList(ids))
+		if err != nil {
+			t.Fatalf("json.Marshal() error = %v, wantErr %v", err, false)
+		}
+
+		expected := `["` + ids[0].String() + `","` + ids[1].String() + `","` + ids[2].String() + `"]`
+		if string(data) != expected {
+			t.Fatalf("json.Marshal() = %s, want %s", data, expected)
+		}
+
+		var decoded LDIDList
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v, wantErr %v", err, false)
+		}
+
+		if len(decoded) != len(ids) {
+			t.Fatalf("json.Unmarshal() len = %v, want %v", len(decoded), len(ids))
+		}
+
+		for i := range ids {
+			if decoded[i].String() != ids[i].String() {
+				t.Fatalf("json.Unmarshal()[%d] = %v, want %v", i, decoded[i], ids[i])
+			}
+		}
+	})
+
+	t.Run("Empty list", func(t *testing.T) {
+		for _, l := range []LDIDList{nil, {}} {
+			data, err := json.Marshal(l)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v, wantErr %v", err, false)
+			}
+
+			if string(data) != "[]" {
+				t.Fatalf("json.Marshal() = %s, want %s", data, "[]")
+			}
+		}
+
+		var decoded LDIDList
+		if err := json.Unmarshal([]byte("[]"), &decoded); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v, wantErr %v", err, false)
+		}
+
+		if len(decoded) != 0 {
+			t.Fatalf("json.Unmarshal() len = %v, want %v", len(decoded), 0)
+		}
+	})
+
+	t.Run("Null element", func(t *testing.T) {
+		ldid, _ := New()
+
+		data, err := json.Marshal(LDIDList{ldid, nil})
+		if err != nil {
+			t.Fatalf("json.Marshal() error = %v, wantErr %v", err, false)
+		}
+
+		expected := `["` + ldid.String() + `",null]`
+		if string(data) != expected {
+			t.Fatalf("json.Marshal() = %s, want %s", data, expected)
+		}
+
+		var decoded LDIDList
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v, wantErr %v", err, false)
+		}
+
+		if len(decoded) != 2 || decoded[1] != nil {
+			t.Fatalf("json.Unmarshal() = %v, want second element nil", decoded)
+		}
+	})
+
+	t.Run("Invalid element", func(t *testing.T) {
+		var decoded LDIDList
+		if err := json.Unmarshal([]byte(`["not-an-id"]`), &decoded); err == nil {
+			t.Fatalf("json.Unmarshal() error = %v, wantErr true", err)
+		}
+	})
+}
+
+func TestParseList(t *testing.T) {
+	t.Run("Valid strings", func(t *testing.T) {
+		strs := []string{
+			"018cc251-f400-7abc-8def-0123456789ab",
+			"018cc251-f401-7abc-8def-0123456789ab",
+		}
+
+		l, err := ParseList(strs)
+		if err != nil {
+			t.Fatalf("ParseList() error = %v, wantErr %v", err, false)
+		}
+
+		for i := range strs {
+			if l[i].String() != strs[i] {
+				t.Fatalf("ParseList()[%d] = %v, want %v", i, l[i], strs[i])
+			}
+		}
+	})
+
+	t.Run("Invalid string", func(t *testing.T) {
+		if _, err := ParseList([]string{"018cc251-f400-7abc-8def-0123456789ab", "bad"}); err == nil {
+			t.Fatalf("ParseList() error = %v, wantErr true", err)
+		}
+	})
+}
+
+func TestParseDelimited(t *testing.T) {
+	a := "018cc251-f400-7abc-8def-0123456789ab"
+	b := "018cc251-f401-7abc-8def-0123456789ab"
+	c := "018cc251-f402-7abc-8def-0123456789ab"
+
+	t.Run("Mixed separators", func(t *testing.T) {
+		l, err := ParseDelimited(" " + a + ", " + b + ",,\t" + c + "\n")
+		if err != nil {
+			t.Fatalf("ParseDelimited() error = %v, wantErr %v", err, false)
+		}
+
+		expected := []string{a, b, c}
+		if len(l) != len(expected) {
+			t.Fatalf("ParseDelimited() len = %v, want %v", len(l), len(expected))
+		}
+
+		for i := range expected {
+			if l[i].String() != expected[i] {
+				t.Fatalf("ParseDelimited()[%d] = %v, want %v", i, l[i], expected[i])
+			}
+		}
+	})
+
+	t.Run("Empty input", func(t *testing.T) {
+		l, err := ParseDelimited(" , ")
+		if err != nil {
+			t.Fatalf("ParseDelimited() error = %v, wantErr %v", err, false)
+		}
+
+		if len(l) != 0 {
+			t.Fatalf("ParseDelimited() len = %v, want %v", len(l), 0)
+		}
+	})
+
+	t.Run("Malformed token", func(t *testing.T) {
+		_, err := ParseDelimited(a + ", bad-token, " + c)
+		if err == nil {
+			t.Fatalf("ParseDelimited() error = %v, wantErr true", err)
+		}
+
+		if !strings.Contains(err.Error(), `index 1 ("bad-token")`) {
+			t.Fatalf("ParseDelimited() error = %v, want index and token of the failure", err)
+		}
+	})
+}
+
+func TestParseStrings(t *testing.T) {
+	a := "018cc251-f400-7abc-8def-0123456789ab"
+	b := "018cc251-f401-7abc-8def-0123456789ab"
+
+	t.Run("Round trip", func(t *testing.T) {
+		ids, err := ParseStrings([]string{a, b})
+		if err != nil {
+			t.Fatalf("ParseStrings() error = %v, wantErr %v", err, false)
+		}
+
+		strs := Strings(ids)
+		if len(strs) != 2 || strs[0] != a || strs[1] != b {
+			t.Fatalf("Strings() = %v, want %v", strs, []string{a, b})
+		}
+	})
+
+	t.Run("Fail fast", func(t *testing.T) {
+		ids, err := ParseStrings([]string{a, "bad", b, "worse"})
+		if err == nil {
+			t.Fatalf("ParseStrings() error = %v, wantErr true", err)
+		}
+
+		if !strings.Contains(err.Error(), "index 1") {
+			t.Fatalf("ParseStrings() error = %v, want index 1", err)
+		}
+
+		if ids != nil {
+			t.Fatalf("ParseStrings() = %v, want nil", ids)
+		}
+	})
+
+	t.Run("Collect all", func(t *testing.T) {
+		ids, errs := ParseStringsAll([]string{a, "bad", b, "worse"})
+		if len(errs) != 4 {
+			t.Fatalf("ParseStringsAll() errors len = %v, want %v", len(errs), 4)
+		}
+
+		for i, wantErr := range []bool{false, true, false, true} {
+			if (errs[i] != nil) != wantErr {
+				t.Fatalf("ParseStringsAll() error[%d] = %v, wantErr %v", i, errs[i], wantErr)
+			}
+
+			if (ids[i] == nil) != wantErr {
+				t.Fatalf("ParseStringsAll()[%d] = %v, want nil %v", i, ids[i], wantErr)
+			}
+		}
+
+		if ids[2].String() != b {
+			t.Fatalf("ParseStringsAll()[2] = %v, want %v", ids[2], b)
+		}
+	})
+
+	t.Run("Collect all valid", func(t *testing.T) {
+		if _, errs := ParseStringsAll([]string{a, b}); errs != nil {
+			t.Fatalf("ParseStringsAll() errors = %v, want nil", errs)
+		}
+	})
+
+	t.Run("Nil elements", func(t *testing.T) {
+		if strs := Strings([]*LDID{nil}); strs[0] != "" {
+			t.Fatalf("Strings() = %q, want empty string", strs)
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package id
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithMAC(t *testing.T) {
+	key := []byte("secret key")
+
+	f, err := NewFactory(WithMAC(key), WithTypeTag(3), WithEnvironmentBit(true))
+	if err != nil {
+		t.Fatalf("NewFactory() error = %v, wantErr %v", err, false)
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		ldid, err := f.New()
+		if err != nil {
+			t.Fatalf("New() error = %v, wantErr %v", err, false)
+		}
+
+		if valid, err := ldid.VerifyMAC(key); err != nil || !valid {
+			t.Fatalf("VerifyMAC() = %v, %v, want %v, nil", valid, err, true)
+		}
+
+		if !ldid.IsValid() {
+			t.Fatalf("New() = %v, want valid LDID", ldid)
+		}
+
+		if tag, _ := ldid.TypeTag(); tag != 3 {
+			t.Fatalf("TypeTag() = %v, want %v", tag, 3)
+		}
+	})
+
+	t.Run("Tampered", func(t *testing.T) {
+		ldid, _ := f.New()
+
+		for _, i := range []int{0, 7, 11, 15} {
+			b := ldid.ProtoBytes()
+			b[i] ^= 0x01
+			tampered := fromBytes(b)
+
+			if valid, _ := tampered.VerifyMAC(key); valid {
+				t.Fatalf("VerifyMAC() = %v for byte %d flipped, want %v", valid, i, false)
+			}
+		}
+	})
+
+	t.Run("Wrong key", func(t *testing.T) {
+		ldid, _ := f.New()
+
+		if valid, _ := ldid.VerifyMAC([]byte("other key")); valid {
+			t.Fatalf("VerifyMAC() = %v with wrong key, want %v", valid, false)
+		}
+	})
+
+	t.Run("Empty key", func(t *testing.T) {
+		if _, err := NewFactory(WithMAC(nil)); err == nil {
+			t.Fatalf("NewFactory() error = %v, wantErr true", err)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, err := (*LDID)(nil).VerifyMAC(key); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("VerifyMAC() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestDeterministicForTime(t *testing.T) {
+	ts := time.Date(2024, time.January, 2, 3, 4, 5, 6000000, time.UTC)
+	key := []byte("cache key")
+
+	t.Run("Deterministic", func(t *testing.T) {
+		a := DeterministicForTime(ts, key)
+		b := DeterministicForTime(ts.Add(500*time.Microsecond), key)
+
+		if a.String() != b.String() {
+			t.Fatalf("DeterministicForTime() = %v and %v, want equal", a, b)
+		}
+
+		if tm, _ := a.Time(); !tm.Equal(ts) {
+			t.Fatalf("Time() = %v, want %v", tm, ts)
+		}
+
+		if !a.IsValid() {
+			t.Fatalf("DeterministicForTime() = %v, want valid LDID", a)
+		}
+	})
+
+	t.Run("Different key differs", func(t *testing.T) {
+		a := DeterministicForTime(ts, key)
+		b := DeterministicForTime(ts, []byte("other key"))
+
+		if a.String() == b.String() {
+			t.Fatalf("DeterministicForTime() = %v for both keys, want different", a)
+		}
+	})
+
+	t.Run("Different time differs", func(t *testing.T) {
+		a := DeterministicForTime(ts, key)
+		b := DeterministicForTime(ts.Add(time.Millisecond), key)
+
+		if a.IdentityKey() == b.IdentityKey() {
+			t.Fatalf("DeterministicForTime() random data equal for %v and %v, want different", a, b)
+		}
+	})
+}
@@ -0,0 +1,173 @@
+package id
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrInvalidVersion is returned when an LDID does not have version 7.
+	ErrInvalidVersion = errors.New("invalid version")
+	// ErrInvalidVariant is returned when an LDID does not have the RFC variant.
+	ErrInvalidVariant = errors.New("invalid variant")
+)
+
+// validate returns an error unless the LDID has version 7 and the RFC variant.
+func (id *LDID) validate() error {
+	version, err := id.Version()
+	if err != nil {
+		return err
+	}
+
+	if version != 0b0111 {
+		return fmt.Errorf("%w: got %d, want 7", ErrInvalidVersion, version)
+	}
+
+	variant, err := id.Variant()
+	if err != nil {
+		return err
+	}
+
+	if variant != 0b10 {
+		return fmt.Errorf("%w: got %b, want 10", ErrInvalidVariant, variant)
+	}
+
+	return nil
+}
+
+// IsValid reports whether the LDID has version 7 and the RFC variant. It returns false for a
+// nil LDID.
+func (id *LDID) IsValid() bool {
+	return id.validate() == nil
+}
+
+// IsDeterministic reports whether the LDID has a name-based version, 3 (MD5) or 5 (SHA-1),
+// which is reproducible from its namespace and name. It is false for all other versions,
+// including v7 LDIDs made reproducible in other ways, such as by DeterministicForTime.
+func (id *LDID) IsDeterministic() (bool, error) {
+	version, err := id.Version()
+	if err != nil {
+		return false, err
+	}
+
+	return version == 3 || version == 5, nil
+}
+
+// WithFixedMetadata returns a copy of the LDID with the version set to 7 and the variant to
+// the RFC variant, repairing IDs from producers that set them wrong so they pass IsValid. Only
+// these 6 bits change; the timestamp and random data are kept as they are. It returns nil for
+// a nil LDID.
+func (id *LDID) WithFixedMetadata() *LDID {
+	if id.isNil() {
+		return nil
+	}
+
+	fixed := fromBytes(id.bf.Bytes())
+	fixed.bf.InsertUint64(versionOffset, versionSize, 0b0111)
+	fixed.bf.InsertUint64(variantOffset, variantSize, 0b10)
+
+	return fixed
+}
+
+// hasRFCMetadata reports whether the LDID carries a version defined by RFC 9562 (1 through 8)
+// and the RFC variant.
+func (id *LDID) hasRFCMetadata() bool {
+	version, err := id.Version()
+	if err != nil {
+		return false
+	}
+
+	variant, err := id.Variant()
+	if err != nil {
+		return false
+	}
+
+	return version >= 1 && version <= 8 && variant == 0b10
+}
+
+// ByteSwapped returns a new LDID with the 16 bytes in reverse order, or nil for a nil LDID.
+func (id *LDID) ByteSwapped() *LDID {
+	if id.isNil() {
+		return nil
+	}
+
+	src := id.bf.Bytes()
+	b := make([]byte, ByteLength)
+
+	for i := range b {
+		b[i] = src[ByteLength-1-i]
+	}
+
+	return fromBytes(b)
+}
+
+// LooksByteSwapped reports whether the LDID appears to have been stored in reversed byte order:
+// its own version and variant are invalid, but those of its byte swapped form are valid.
+// This is a heuristic; random bytes can occasionally produce a false positive.
+func (id *LDID) LooksByteSwapped() bool {
+	if id.isNil() {
+		return false
+	}
+
+	return !id.hasRFCMetadata() && id.ByteSwapped().hasRFCMetadata()
+}
+
+// versionNames maps the versions defined by RFC 9562 to human-readable names.
+var versionNames = map[uint64]string{
+	1: "v1 (gregorian time)",
+	2: "v2 (DCE security)",
+	3: "v3 (name-based, MD5)",
+	4: "v4 (random)",
+	5: "v5 (name-based, SHA-1)",
+	6: "v6 (reordered gregorian time)",
+	7: "v7 (unix time, random)",
+	8: "v8 (custom)",
+}
+
+// VersionName returns a human-readable name for the version of the LDID, such as
+// "v7 (unix time, random)". The all-zero and all-one values are reported as "nil" and "max".
+// Versions not defined by RFC 9562 are reported as "unknown (n)".
+func (id *LDID) VersionName() (string, error) {
+	if id.isNil() {
+		return "", ErrNilLDID
+	}
+
+	switch string(id.bf.Bytes()) {
+	case string(make([]byte, ByteLength)):
+		return "nil", nil
+	case strings.Repeat("\xff", ByteLength):
+		return "max", nil
+	}
+
+	version, err := id.Version()
+	if err != nil {
+		return "", err
+	}
+
+	if name, ok := versionNames[version]; ok {
+		return name, nil
+	}
+
+	return fmt.Sprintf("unknown (%d)", version), nil
+}
+
+// VariantName returns a human-readable name for the variant of the LDID: "NCS", "RFC 9562",
+// "Microsoft" or "future".
+func (id *LDID) VariantName() (string, error) {
+	variant, err := id.Variant()
+	if err != nil {
+		return "", err
+	}
+
+	switch variant {
+	case 0b0:
+		return "NCS", nil
+	case 0b10:
+		return "RFC 9562", nil
+	case 0b110:
+		return "Microsoft", nil
+	default:
+		return "future", nil
+	}
+}
@@ -0,0 +1,214 @@
+package id
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestByteSwapped(t *testing.T) {
+	ldid, _ := FromString("018cc251-f400-7abc-8def-0123456789ab")
+	expected := "ab896745-2301-ef8d-bc7a-00f451c28c01"
+
+	swapped := ldid.ByteSwapped()
+
+	if swapped.String() != expected {
+		t.Fatalf("ByteSwapped() = %v, want %v", swapped, expected)
+	}
+
+	if back := swapped.ByteSwapped(); back.String() != ldid.String() {
+		t.Fatalf("ByteSwapped().ByteSwapped() = %v, want %v", back, ldid)
+	}
+
+	if (*LDID)(nil).ByteSwapped() != nil {
+		t.Fatalf("ByteSwapped() on nil LDID, want nil")
+	}
+}
+
+func TestLooksByteSwapped(t *testing.T) {
+	ldid, _ := FromString("018cc251-f400-7abc-8def-0123456789ab")
+	swapped, _ := FromString("ab896745-2301-ef8d-bc7a-00f451c28c01")
+
+	if ldid.LooksByteSwapped() {
+		t.Fatalf("LooksByteSwapped() = %v, want %v", true, false)
+	}
+
+	if !swapped.LooksByteSwapped() {
+		t.Fatalf("LooksByteSwapped() = %v, want %v", false, true)
+	}
+
+	if (*LDID)(nil).LooksByteSwapped() {
+		t.Fatalf("LooksByteSwapped() on nil LDID = %v, want %v", true, false)
+	}
+}
+
+func TestVersionName(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"00000000-0000-0000-0000-000000000000", "nil"},
+		{"ffffffff-ffff-ffff-ffff-ffffffffffff", "max"},
+		{"018cc251-f400-1abc-8def-0123456789ab", "v1 (gregorian time)"},
+		{"018cc251-f400-4abc-8def-0123456789ab", "v4 (random)"},
+		{"018cc251-f400-5abc-8def-0123456789ab", "v5 (name-based, SHA-1)"},
+		{"018cc251-f400-7abc-8def-0123456789ab", "v7 (unix time, random)"},
+		{"018cc251-f400-0abc-8def-0123456789ab", "unknown (0)"},
+		{"018cc251-f400-fabc-8def-0123456789ab", "unknown (15)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			ldid, _ := FromString(tt.input)
+
+			name, err := ldid.VersionName()
+			if err != nil {
+				t.Fatalf("VersionName() error = %v, wantErr %v", err, false)
+			}
+
+			if name != tt.expected {
+				t.Fatalf("VersionName() = %v, want %v", name, tt.expected)
+			}
+		})
+	}
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, err := (*LDID)(nil).VersionName(); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("VersionName() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestVariantName(t *testing.T) {
+	tests := []struct {
+		input    string
+		variant  uint64
+		expected string
+	}{
+		{"018cc251-f400-7abc-0def-0123456789ab", 0b0, "NCS"},
+		{"018cc251-f400-7abc-7def-0123456789ab", 0b0, "NCS"},
+		{"018cc251-f400-7abc-8def-0123456789ab", 0b10, "RFC 9562"},
+		{"018cc251-f400-7abc-bdef-0123456789ab", 0b10, "RFC 9562"},
+		{"018cc251-f400-7abc-cdef-0123456789ab", 0b110, "Microsoft"},
+		{"018cc251-f400-7abc-ddef-0123456789ab", 0b110, "Microsoft"},
+		{"018cc251-f400-7abc-edef-0123456789ab", 0b111, "future"},
+		{"018cc251-f400-7abc-fdef-0123456789ab", 0b111, "future"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			ldid, _ := FromString(tt.input)
+
+			if variant, _ := ldid.Variant(); variant != tt.variant {
+				t.Fatalf("Variant() = %b, want %b", variant, tt.variant)
+			}
+
+			name, err := ldid.VariantName()
+			if err != nil {
+				t.Fatalf("VariantName() error = %v, wantErr %v", err, false)
+			}
+
+			if name != tt.expected {
+				t.Fatalf("VariantName() = %v, want %v", name, tt.expected)
+			}
+		})
+	}
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, err := (*LDID)(nil).VariantName(); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("VariantName() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestIsValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{"Version 7", "018cc251-f400-7abc-8def-0123456789ab", true},
+		{"Version 4", "018cc251-f400-4abc-8def-0123456789ab", false},
+		{"Microsoft variant", "018cc251-f400-7abc-cdef-0123456789ab", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ldid, _ := FromString(tt.input)
+
+			if valid := ldid.IsValid(); valid != tt.expected {
+				t.Fatalf("IsValid() = %v, want %v", valid, tt.expected)
+			}
+		})
+	}
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if valid := (*LDID)(nil).IsValid(); valid {
+			t.Fatalf("IsValid() = %v, want %v", valid, false)
+		}
+	})
+}
+
+func TestWithFixedMetadata(t *testing.T) {
+	t.Run("Version 0", func(t *testing.T) {
+		ldid, _ := FromString("018cc251-f400-0abc-0def-0123456789ab")
+
+		fixed := ldid.WithFixedMetadata()
+
+		if version, _ := fixed.Version(); version != 7 {
+			t.Fatalf("WithFixedMetadata() version = %v, want %v", version, 7)
+		}
+
+		if !fixed.IsValid() {
+			t.Fatalf("WithFixedMetadata() = %v, want valid LDID", fixed)
+		}
+
+		if fixed.String() != "018cc251-f400-7abc-8def-0123456789ab" {
+			t.Fatalf("WithFixedMetadata() = %v, want %v", fixed, "018cc251-f400-7abc-8def-0123456789ab")
+		}
+
+		if ldid.String() != "018cc251-f400-0abc-0def-0123456789ab" {
+			t.Fatalf("WithFixedMetadata() modified LDID to %v", ldid)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if fixed := (*LDID)(nil).WithFixedMetadata(); fixed != nil {
+			t.Fatalf("WithFixedMetadata() = %v, want nil", fixed)
+		}
+	})
+}
+
+func TestIsDeterministic(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{"Version 3", "6fa459ea-ee8a-3ca4-894e-db77e160355e", true},
+		{"Version 4", "919108f7-52d1-4320-9bac-f847db4148a8", false},
+		{"Version 5", "886313e1-3b8a-5372-9b90-0c9aee199e5d", true},
+		{"Version 7", "018cc251-f400-7abc-8def-0123456789ab", false},
+		{"Nil UUID", "00000000-0000-0000-0000-000000000000", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ldid, _ := FromString(tt.input)
+
+			deterministic, err := ldid.IsDeterministic()
+			if err != nil {
+				t.Fatalf("IsDeterministic() error = %v, wantErr %v", err, false)
+			}
+
+			if deterministic != tt.expected {
+				t.Fatalf("IsDeterministic() = %v, want %v", deterministic, tt.expected)
+			}
+		})
+	}
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, err := (*LDID)(nil).IsDeterministic(); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("IsDeterministic() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package id
+
+import (
+	"io"
+	"time"
+)
+
+// microsecondBits is the number of leftmost RandA bits holding the microsecond within the millisecond.
+const microsecondBits = 10
+
+// MicrosecondGenerator is a Generator that stores the microsecond within the millisecond
+// (0-999) in the leftmost 10 bits of RandA, and random data in the remaining 2 bits. LDIDs
+// created within the same millisecond then sort by microsecond, raising the ordering
+// resolution from 1ms to 1µs at the cost of 10 bits of entropy: 64 random bits remain per
+// LDID instead of 74.
+//
+// The microsecond is taken from the time read by the preceding GenerateUnixTimestampMS call.
+// A MicrosecondGenerator is not safe for concurrent use: a call from another goroutine in
+// between would give the LDID the microsecond of a different clock read, possibly from a
+// different millisecond. Use a generator per goroutine.
+type MicrosecondGenerator struct {
+	// Clock returns the current time. When nil, time.Now is used.
+	Clock func() time.Time
+	// Reader is the source of random data. When nil, crypto/rand.Reader is used.
+	Reader io.Reader
+
+	last time.Time
+}
+
+func (g *MicrosecondGenerator) GenerateUnixTimestampMS() uint64 {
+	now := time.Now
+	if g.Clock != nil {
+		now = g.Clock
+	}
+
+	g.last = now()
+
+	return uint64(g.last.UnixMilli())
+}
+
+func (g *MicrosecondGenerator) GenerateRandomBits(randReader io.Reader, n int64) (uint64, error) {
+	d := &DefaultGenerator{Reader: g.Reader}
+
+	if n != int64(randASize) {
+		return d.GenerateRandomBits(randReader, n)
+	}
+
+	micro := uint64(g.last.Nanosecond()/int(time.Microsecond)) % 1000
+
+	rb, err := d.GenerateRandomBits(randReader, int64(randASize)-microsecondBits)
+	if err != nil {
+		return 0, err
+	}
+
+	return micro<<(randASize-microsecondBits) | rb, nil
+}
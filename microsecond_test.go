@@ -0,0 +1,70 @@
+package id
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestMicrosecondGenerator(t *testing.T) {
+	t.Run("Microsecond in RandA", func(t *testing.T) {
+		g := &MicrosecondGenerator{
+			Clock: func() time.Time {
+				return time.Date(2024, time.January, 2, 3, 4, 5, 6789000, time.UTC)
+			},
+		}
+
+		ldid, err := NewWithGenerator(g)
+		if err != nil {
+			t.Fatalf("NewWithGenerator() error = %v, wantErr %v", err, false)
+		}
+
+		randA, _ := ldid.RandA()
+		if micro := randA >> (randASize - microsecondBits); micro != 789 {
+			t.Fatalf("RandA() microseconds = %v, want %v", micro, 789)
+		}
+	})
+
+	t.Run("Rapid successive calls sort in order", func(t *testing.T) {
+		// Every call advances the clock by one microsecond, so many IDs share a millisecond
+		ts := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+		g := &MicrosecondGenerator{
+			Clock: func() time.Time {
+				ts = ts.Add(time.Microsecond)
+				return ts
+			},
+		}
+
+		var previous *LDID
+		for i := 0; i < 3000; i++ {
+			ldid, err := NewWithGenerator(g)
+			if err != nil {
+				t.Fatalf("NewWithGenerator() error = %v, wantErr %v", err, false)
+			}
+
+			if previous != nil && bytes.Compare(previous.Bytes(), ldid.Bytes()) >= 0 {
+				t.Fatalf("NewWithGenerator() = %v after %v, want ascending order", ldid, previous)
+			}
+			previous = ldid
+		}
+	})
+
+	t.Run("Other fields", func(t *testing.T) {
+		ldid, err := NewWithGenerator(&MicrosecondGenerator{})
+		if err != nil {
+			t.Fatalf("NewWithGenerator() error = %v, wantErr %v", err, false)
+		}
+
+		if err := ldid.validate(); err != nil {
+			t.Fatalf("NewWithGenerator() = %v, want valid LDID: %v", ldid, err)
+		}
+	})
+
+	t.Run("Reader failing", func(t *testing.T) {
+		g := &MicrosecondGenerator{Reader: &MockRandomReader{}}
+
+		if _, err := NewWithGenerator(g); err == nil {
+			t.Fatalf("NewWithGenerator() error = %v, wantErr true", err)
+		}
+	})
+}
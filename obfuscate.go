@@ -0,0 +1,50 @@
+package id
+
+import (
+	"crypto/aes"
+	"encoding/base64"
+	"fmt"
+)
+
+// Obfuscate encrypts the 16 bytes of the LDID as a single AES-128 block with the given key and
+// returns the result as 22 characters of unpadded URL-safe base64, for exposing IDs externally
+// without revealing their creation time or order. Deobfuscate with the same key reverses it.
+// It returns an empty string for a nil LDID.
+//
+// The encryption is deterministic: the same LDID and key always produce the same string, so
+// equal outputs still reveal equal IDs. It is not authenticated: Deobfuscate accepts any
+// well-formed input, and tampered strings decode to unrelated, usually invalid, LDIDs. Anyone
+// holding the key can recover the LDID, so keep it secret and treat a leaked key like any
+// other compromised secret.
+func (id *LDID) Obfuscate(key [16]byte) string {
+	if id.isNil() {
+		return ""
+	}
+
+	// NewCipher only fails for invalid key sizes, and a [16]byte is always valid
+	block, _ := aes.NewCipher(key[:])
+
+	dst := make([]byte, ByteLength)
+	block.Encrypt(dst, id.bf.Bytes())
+
+	return base64.RawURLEncoding.EncodeToString(dst)
+}
+
+// Deobfuscate decrypts a string returned by Obfuscate with the same key into a new LDID.
+func Deobfuscate(s string, key [16]byte) (*LDID, error) {
+	if len(s) != Base64Length {
+		return &LDID{}, fmt.Errorf("failed to deobfuscate LDID: invalid length %d, want %d", len(s), Base64Length)
+	}
+
+	src, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return &LDID{}, fmt.Errorf("failed to deobfuscate LDID: %w", err)
+	}
+
+	block, _ := aes.NewCipher(key[:])
+
+	dst := make([]byte, ByteLength)
+	block.Decrypt(dst, src)
+
+	return fromBytes(dst), nil
+}
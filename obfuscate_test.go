@@ -0,0 +1,65 @@
+package id
+
+import (
+	"testing"
+)
+
+func TestObfuscate(t *testing.T) {
+	key := [16]byte{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f}
+
+	t.Run("Round trip", func(t *testing.T) {
+		ldid, _ := New()
+
+		s := ldid.Obfuscate(key)
+		if len(s) != Base64Length {
+			t.Fatalf("Obfuscate() len = %v, want %v", len(s), Base64Length)
+		}
+
+		decoded, err := Deobfuscate(s, key)
+		if err != nil {
+			t.Fatalf("Deobfuscate() error = %v, wantErr %v", err, false)
+		}
+
+		if decoded.String() != ldid.String() {
+			t.Fatalf("Deobfuscate() = %v, want %v", decoded, ldid)
+		}
+	})
+
+	t.Run("Hides shared prefix", func(t *testing.T) {
+		a, _ := FromString("018cc251-f400-7abc-8def-0123456789ab")
+		b, _ := FromString("018cc251-f400-7abc-8def-0123456789ac")
+
+		if sa, sb := a.Obfuscate(key), b.Obfuscate(key); sa[:8] == sb[:8] {
+			t.Fatalf("Obfuscate() = %v and %v, want unrelated outputs", sa, sb)
+		}
+	})
+
+	t.Run("Wrong key", func(t *testing.T) {
+		ldid, _ := New()
+		other := key
+		other[0] ^= 1
+
+		decoded, err := Deobfuscate(ldid.Obfuscate(key), other)
+		if err != nil {
+			t.Fatalf("Deobfuscate() error = %v, wantErr %v", err, false)
+		}
+
+		if decoded.String() == ldid.String() {
+			t.Fatalf("Deobfuscate() = %v, want different LDID with wrong key", decoded)
+		}
+	})
+
+	t.Run("Invalid input", func(t *testing.T) {
+		for _, input := range []string{"", "short", "!!!!!!!!!!!!!!!!!!!!!!", "AAAAAAAAAAAAAAAAAAAAAAAA"} {
+			if _, err := Deobfuscate(input, key); err == nil {
+				t.Fatalf("Deobfuscate(%q) error = %v, wantErr true", input, err)
+			}
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if s := (*LDID)(nil).Obfuscate(key); s != "" {
+			t.Fatalf("Obfuscate() = %v, want empty string", s)
+		}
+	})
+}
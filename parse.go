@@ -0,0 +1,118 @@
+package id
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Parse parses the canonical string representation of a UUID into a new LDID. Unlike
+// FromString, it requires exactly 36 characters with hyphens at the canonical positions.
+// It checks the structure only; use ParseValid to also check the version and variant.
+func Parse(s string) (*LDID, error) {
+	if len(s) != CanonicalLength {
+		return &LDID{}, fmt.Errorf("failed to parse LDID: invalid length %d, want %d", len(s), CanonicalLength)
+	}
+
+	for _, i := range []int{8, 13, 18, 23} {
+		if s[i] != '-' {
+			return &LDID{}, fmt.Errorf("failed to parse LDID: expected '-' at position %d, got %q", i, s[i])
+		}
+	}
+
+	b, err := hex.DecodeString(s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:36])
+	if err != nil {
+		return &LDID{}, fmt.Errorf("failed to parse LDID: %w", err)
+	}
+
+	return fromBytes(b), nil
+}
+
+// ParseValid parses s like Parse and additionally requires version 7 and the RFC variant,
+// returning ErrInvalidVersion or ErrInvalidVariant otherwise.
+func ParseValid(s string) (*LDID, error) {
+	id, err := Parse(s)
+	if err != nil {
+		return &LDID{}, err
+	}
+
+	if err := id.validate(); err != nil {
+		return &LDID{}, fmt.Errorf("failed to parse LDID: %w", err)
+	}
+
+	return id, nil
+}
+
+// ValidateAll checks every string with ParseValid and returns the errors by index: the error
+// at index i belongs to strs[i] and is nil if that string is valid. It returns nil if all
+// strings are valid.
+func ValidateAll(strs []string) []error {
+	var errs []error
+
+	for i, s := range strs {
+		if _, err := ParseValid(s); err != nil {
+			if errs == nil {
+				errs = make([]error, len(strs))
+			}
+			errs[i] = err
+		}
+	}
+
+	return errs
+}
+
+// urnPrefix is the prefix of the URN representation of a UUID (RFC 9562 section 4).
+const urnPrefix = "urn:uuid:"
+
+// ParseAny parses an LDID in any supported representation, detected by its prefix and length:
+// a URN (urn:uuid: followed by the canonical form), canonical (36 characters), hex (32),
+// base32 as returned by Base32 (26) or base64 as returned by Base64 (22). Hex and base32 are
+// accepted in either letter case.
+func ParseAny(s string) (*LDID, error) {
+	if len(s) >= len(urnPrefix) && strings.EqualFold(s[:len(urnPrefix)], urnPrefix) {
+		return Parse(s[len(urnPrefix):])
+	}
+
+	var b []byte
+	var err error
+
+	switch len(s) {
+	case CanonicalLength:
+		return Parse(s)
+	case HexLength:
+		b, err = hex.DecodeString(s)
+	case Base32Length:
+		b, err = base32Encoding.DecodeString(strings.ToLower(s))
+	case Base64Length:
+		b, err = base64.RawURLEncoding.DecodeString(s)
+	default:
+		return &LDID{}, fmt.Errorf("failed to parse LDID: no format with length %d", len(s))
+	}
+
+	if err != nil {
+		return &LDID{}, fmt.Errorf("failed to parse LDID: %w", err)
+	}
+
+	if len(b) != ByteLength {
+		return &LDID{}, fmt.Errorf("failed to parse LDID: decoded %d bytes, want %d", len(b), ByteLength)
+	}
+
+	return fromBytes(b), nil
+}
+
+// Canonicalize normalizes an LDID in any representation accepted by ParseAny, or the canonical
+// form in braces as used by Microsoft tools, to the lowercase canonical form of String. It
+// returns an error if s is in none of these representations.
+func Canonicalize(s string) (string, error) {
+	if len(s) == CanonicalLength+2 && s[0] == '{' && s[len(s)-1] == '}' {
+		s = s[1 : len(s)-1]
+	}
+
+	id, err := ParseAny(s)
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
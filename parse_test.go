@@ -0,0 +1,209 @@
+package id
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("Canonical string", func(t *testing.T) {
+		s := "018cc251-f400-7abc-8def-0123456789ab"
+
+		ldid, err := Parse(s)
+		if err != nil {
+			t.Fatalf("Parse() error = %v, wantErr %v", err, false)
+		}
+
+		if ldid.String() != s {
+			t.Fatalf("Parse() = %v, want %v", ldid, s)
+		}
+	})
+
+	t.Run("Any version", func(t *testing.T) {
+		if _, err := Parse("018cc251-f400-4abc-cdef-0123456789ab"); err != nil {
+			t.Fatalf("Parse() error = %v, wantErr %v", err, false)
+		}
+	})
+
+	t.Run("Invalid structure", func(t *testing.T) {
+		inputs := []string{
+			"",
+			"018cc251f4007abc8def0123456789ab",
+			"018cc251-f400-7abc-8def-0123456789a",
+			"018cc251-f4007-abc-8def-0123456789ab",
+			"018cc251-f400-7abc-8def-0123456789ag",
+		}
+
+		for _, input := range inputs {
+			if _, err := Parse(input); err == nil {
+				t.Fatalf("Parse(%q) error = %v, wantErr true", input, err)
+			}
+		}
+	})
+}
+
+func TestParseValid(t *testing.T) {
+	t.Run("Valid LDID", func(t *testing.T) {
+		ldid, err := New()
+		if err != nil {
+			t.Fatalf("New() error = %v, wantErr %v", err, false)
+		}
+
+		if _, err := ParseValid(ldid.String()); err != nil {
+			t.Fatalf("ParseValid() error = %v, wantErr %v", err, false)
+		}
+	})
+
+	t.Run("Version 4", func(t *testing.T) {
+		if _, err := ParseValid("018cc251-f400-4abc-8def-0123456789ab"); !errors.Is(err, ErrInvalidVersion) {
+			t.Fatalf("ParseValid() error = %v, want %v", err, ErrInvalidVersion)
+		}
+	})
+
+	t.Run("Microsoft variant", func(t *testing.T) {
+		if _, err := ParseValid("018cc251-f400-7abc-cdef-0123456789ab"); !errors.Is(err, ErrInvalidVariant) {
+			t.Fatalf("ParseValid() error = %v, want %v", err, ErrInvalidVariant)
+		}
+	})
+
+	t.Run("Invalid structure", func(t *testing.T) {
+		if _, err := ParseValid("not-an-id"); err == nil {
+			t.Fatalf("ParseValid() error = %v, wantErr true", err)
+		}
+	})
+}
+
+func TestValidateAll(t *testing.T) {
+	t.Run("Mixed input", func(t *testing.T) {
+		strs := []string{
+			"018cc251-f400-7abc-8def-0123456789ab",
+			"not-an-id",
+			"018cc251-f400-4abc-8def-0123456789ab",
+			"018cc251-f400-7abc-8def-0123456789ab",
+		}
+
+		errs := ValidateAll(strs)
+		if len(errs) != len(strs) {
+			t.Fatalf("ValidateAll() len = %v, want %v", len(errs), len(strs))
+		}
+
+		if errs[0] != nil || errs[3] != nil {
+			t.Fatalf("ValidateAll() = %v, want nil errors at index 0 and 3", errs)
+		}
+
+		if errs[1] == nil {
+			t.Fatalf("ValidateAll()[1] = %v, want error", errs[1])
+		}
+
+		if !errors.Is(errs[2], ErrInvalidVersion) {
+			t.Fatalf("ValidateAll()[2] = %v, want %v", errs[2], ErrInvalidVersion)
+		}
+	})
+
+	t.Run("All valid", func(t *testing.T) {
+		if errs := ValidateAll([]string{"018cc251-f400-7abc-8def-0123456789ab"}); errs != nil {
+			t.Fatalf("ValidateAll() = %v, want nil", errs)
+		}
+	})
+}
+
+func TestParseAny(t *testing.T) {
+	ldid, _ := Parse("018cc251-f400-7abc-8def-0123456789ab")
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"Canonical", ldid.String()},
+		{"Canonical uppercase", ldid.StringUpper()},
+		{"URN", "urn:uuid:" + ldid.String()},
+		{"URN uppercase prefix", "URN:UUID:" + ldid.String()},
+		{"Hex", ldid.HexSortable()},
+		{"Base32", ldid.Base32()},
+		{"Base32 uppercase", strings.ToUpper(ldid.Base32())},
+		{"Base64", ldid.Base64()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := ParseAny(tt.input)
+			if err != nil {
+				t.Fatalf("ParseAny(%q) error = %v, wantErr %v", tt.input, err, false)
+			}
+
+			if parsed.String() != ldid.String() {
+				t.Fatalf("ParseAny(%q) = %v, want %v", tt.input, parsed, ldid)
+			}
+		})
+	}
+
+	t.Run("Invalid input", func(t *testing.T) {
+		inputs := []string{
+			"",
+			"018cc251",
+			"urn:uuid:018cc251f4007abc8def0123456789ab",
+			"zzcc251f4007abc8def0123456789abc",      // hex length, not hex
+			"AYzCUfQAerqN7wEjRWeJqw!!!!",            // base32 length, not base32
+			"AYzCUfQAerqN7wEjRWeJq+",                // base64 length, standard alphabet
+			"018cc251-f400-7abc-8def-0123456789ab0", // one character too many
+		}
+
+		for _, input := range inputs {
+			if _, err := ParseAny(input); err == nil {
+				t.Fatalf("ParseAny(%q) error = %v, wantErr true", input, err)
+			}
+		}
+	})
+}
+
+func TestCanonicalize(t *testing.T) {
+	expected := "018cc251-f400-7abc-8def-0123456789ab"
+	ldid, _ := Parse(expected)
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"Canonical", expected},
+		{"Uppercase", ldid.StringUpper()},
+		{"Braces", "{" + expected + "}"},
+		{"Braces uppercase", "{" + ldid.StringUpper() + "}"},
+		{"URN", "urn:uuid:" + expected},
+		{"URN uppercase", "URN:UUID:" + ldid.StringUpper()},
+		{"Hyphenless", ldid.HexSortable()},
+		{"Hyphenless uppercase", strings.ToUpper(ldid.HexSortable())},
+		{"Base32", ldid.Base32()},
+		{"Base64", ldid.Base64()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Canonicalize(tt.input)
+			if err != nil {
+				t.Fatalf("Canonicalize(%q) error = %v, wantErr %v", tt.input, err, false)
+			}
+
+			if s != expected {
+				t.Fatalf("Canonicalize(%q) = %v, want %v", tt.input, s, expected)
+			}
+		})
+	}
+
+	t.Run("Invalid input", func(t *testing.T) {
+		inputs := []string{
+			"",
+			"{}",
+			"{" + ldid.HexSortable() + "}",
+			"{" + expected,
+			"(" + expected + ")",
+			"018cc251-f400-7abc-8def-0123456789zz",
+		}
+
+		for _, input := range inputs {
+			if s, err := Canonicalize(input); err == nil {
+				t.Fatalf("Canonicalize(%q) = %v, wantErr %v", input, s, true)
+			}
+		}
+	})
+}
@@ -0,0 +1,133 @@
+package id
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"time"
+)
+
+// PrefixRange returns the smallest and largest LDIDs starting with the given prefix.
+// The prefix is padded with zero bytes for the minimum and with 0xff bytes for the
+// maximum, so every LDID sharing the prefix sorts between the two. A 6 byte prefix
+// covers exactly one millisecond of the timestamp field.
+func PrefixRange(prefix []byte) (min, max *LDID, err error) {
+	if len(prefix) < 1 || len(prefix) > 16 {
+		return nil, nil, errors.New("failed to compute prefix range: prefix must be 1 to 16 bytes long")
+	}
+
+	lo := make([]byte, 16)
+	hi := make([]byte, 16)
+
+	copy(lo, prefix)
+	copy(hi, prefix)
+
+	for i := len(prefix); i < 16; i++ {
+		hi[i] = 0xff
+	}
+
+	return fromBytes(lo), fromBytes(hi), nil
+}
+
+// PrefixKey returns the timestamp of the LDID rounded down to the given precision as 6
+// big-endian bytes, for use as a coarse key in a time-bucketed index: all LDIDs created
+// within the same bucket share a key, and keys sort by time. Use Bytes as the key of the
+// individual LDID within a bucket.
+//
+// The timestamp has millisecond resolution, so precision must be a positive whole number of
+// milliseconds. PrefixKey returns nil for any other precision or for a nil LDID.
+func (id *LDID) PrefixKey(precision time.Duration) []byte {
+	if precision < time.Millisecond || precision%time.Millisecond != 0 {
+		return nil
+	}
+
+	timestamp, err := id.Timestamp()
+	if err != nil {
+		return nil
+	}
+
+	ms := uint64(precision / time.Millisecond)
+	truncated := timestamp - timestamp%ms
+
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, truncated)
+
+	return key[2:]
+}
+
+// Next returns the smallest LDID greater than this one, by adding one to its 128-bit value.
+// For keyset pagination, query the next page with id >= last.Next(). It returns an error for
+// the maximum value ffffffff-ffff-ffff-ffff-ffffffffffff, which has no successor.
+//
+// The result is a boundary for comparisons and generally has no valid version or variant.
+func (id *LDID) Next() (*LDID, error) {
+	if id.isNil() {
+		return &LDID{}, ErrNilLDID
+	}
+
+	b := id.bf.Bytes()
+	hi := binary.BigEndian.Uint64(b[0:8])
+	lo := binary.BigEndian.Uint64(b[8:16])
+
+	if hi == math.MaxUint64 && lo == math.MaxUint64 {
+		return &LDID{}, errors.New("failed to compute next LDID: overflow at maximum value")
+	}
+
+	lo++
+	if lo == 0 {
+		hi++
+	}
+
+	return fromHalves(hi, lo), nil
+}
+
+// Prev returns the largest LDID smaller than this one, by subtracting one from its 128-bit
+// value. For reverse keyset pagination, query the previous page with id <= first.Prev(), the
+// inclusive form of the exclusive bound id < first. It returns an error for the Nil UUID,
+// which has no predecessor.
+//
+// The result is a boundary for comparisons and generally has no valid version or variant.
+func (id *LDID) Prev() (*LDID, error) {
+	if id.isNil() {
+		return &LDID{}, ErrNilLDID
+	}
+
+	b := id.bf.Bytes()
+	hi := binary.BigEndian.Uint64(b[0:8])
+	lo := binary.BigEndian.Uint64(b[8:16])
+
+	if hi == 0 && lo == 0 {
+		return &LDID{}, errors.New("failed to compute previous LDID: underflow at Nil UUID")
+	}
+
+	if lo == 0 {
+		hi--
+	}
+	lo--
+
+	return fromHalves(hi, lo), nil
+}
+
+// FloorID returns the smallest LDID with the timestamp of t rounded down to the millisecond,
+// with all bits after the timestamp zero, as the lower bound of a query like id >= FloorID(t).
+//
+// Both FloorID and CeilID round outward to whole milliseconds, as LDIDs cannot be ordered
+// within one: for a t between milliseconds, id >= FloorID(t) includes LDIDs created earlier in
+// the same millisecond, and id <= CeilID(t) includes those of the next millisecond.
+func FloorID(t time.Time) *LDID {
+	ms := uint64(t.UnixMilli())
+
+	return fromHalves(ms<<16, 0)
+}
+
+// CeilID returns the largest LDID with the timestamp of t rounded up to the millisecond, with
+// all bits after the timestamp one, as the upper bound of a query like id <= CeilID(t). A t on
+// an exact millisecond is not rounded. See FloorID for the rounding.
+func CeilID(t time.Time) *LDID {
+	ms := uint64(t.UnixMilli())
+	if t.After(time.UnixMilli(int64(ms))) {
+		ms++
+	}
+
+	return fromHalves(ms<<16|0xffff, math.MaxUint64)
+}
@@ -0,0 +1,238 @@
+package id
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPrefixRange(t *testing.T) {
+	t.Run("Full timestamp prefix", func(t *testing.T) {
+		prefix := []byte{0x01, 0x8c, 0xc2, 0x51, 0xf4, 0x00}
+		expectedTimestamp := uint64(0x018cc251f400)
+
+		min, max, err := PrefixRange(prefix)
+		if err != nil {
+			t.Fatalf("PrefixRange() error = %v, wantErr %v", err, false)
+		}
+
+		if min.String() != "018cc251-f400-0000-0000-000000000000" {
+			t.Fatalf("PrefixRange() min = %v, want zero padding", min)
+		}
+
+		if max.String() != "018cc251-f400-ffff-ffff-ffffffffffff" {
+			t.Fatalf("PrefixRange() max = %v, want 0xff padding", max)
+		}
+
+		if timestamp, _ := min.Timestamp(); timestamp != expectedTimestamp {
+			t.Fatalf("min.Timestamp() = %v, want %v", timestamp, expectedTimestamp)
+		}
+
+		if timestamp, _ := max.Timestamp(); timestamp != expectedTimestamp {
+			t.Fatalf("max.Timestamp() = %v, want %v", timestamp, expectedTimestamp)
+		}
+	})
+
+	t.Run("Partial timestamp prefix", func(t *testing.T) {
+		prefix := []byte{0x01, 0x8c, 0xc2}
+		expectedMinTimestamp := uint64(0x018cc2000000)
+		expectedMaxTimestamp := uint64(0x018cc2ffffff)
+
+		min, max, err := PrefixRange(prefix)
+		if err != nil {
+			t.Fatalf("PrefixRange() error = %v, wantErr %v", err, false)
+		}
+
+		if timestamp, _ := min.Timestamp(); timestamp != expectedMinTimestamp {
+			t.Fatalf("min.Timestamp() = %v, want %v", timestamp, expectedMinTimestamp)
+		}
+
+		if timestamp, _ := max.Timestamp(); timestamp != expectedMaxTimestamp {
+			t.Fatalf("max.Timestamp() = %v, want %v", timestamp, expectedMaxTimestamp)
+		}
+	})
+
+	t.Run("Invalid prefix length", func(t *testing.T) {
+		for _, prefix := range [][]byte{nil, {}, make([]byte, 17)} {
+			if _, _, err := PrefixRange(prefix); err == nil {
+				t.Fatalf("PrefixRange(%v) error = %v, wantErr true", prefix, err)
+			}
+		}
+	})
+}
+
+func TestPrefixKey(t *testing.T) {
+	hour := time.Date(2024, time.January, 2, 3, 0, 0, 0, time.UTC)
+
+	t.Run("Hour precision", func(t *testing.T) {
+		a := TestID(hour.Add(time.Minute), 0)
+		b := TestID(hour.Add(59*time.Minute+59*time.Second), 1)
+		c := TestID(hour.Add(time.Hour), 2)
+
+		want := make([]byte, 6)
+		ms := hour.UnixMilli()
+		for i := range want {
+			want[i] = byte(ms >> (40 - 8*i))
+		}
+
+		if key := a.PrefixKey(time.Hour); !bytes.Equal(key, want) {
+			t.Fatalf("PrefixKey() = %x, want %x", key, want)
+		}
+
+		if key := b.PrefixKey(time.Hour); !bytes.Equal(key, want) {
+			t.Fatalf("PrefixKey() = %x, want %x", key, want)
+		}
+
+		if key := c.PrefixKey(time.Hour); bytes.Compare(key, want) <= 0 {
+			t.Fatalf("PrefixKey() = %x, want greater than %x", key, want)
+		}
+
+		if key := a.PrefixKey(time.Millisecond); !bytes.Equal(key, a.Bytes()[:6]) {
+			t.Fatalf("PrefixKey() = %x, want %x", key, a.Bytes()[:6])
+		}
+	})
+
+	t.Run("Invalid precision", func(t *testing.T) {
+		ldid := TestID(hour, 0)
+
+		for _, precision := range []time.Duration{0, -time.Hour, time.Microsecond, 1500 * time.Microsecond} {
+			if key := ldid.PrefixKey(precision); key != nil {
+				t.Fatalf("PrefixKey(%v) = %x, want nil", precision, key)
+			}
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if key := (*LDID)(nil).PrefixKey(time.Hour); key != nil {
+			t.Fatalf("PrefixKey() = %x, want nil", key)
+		}
+	})
+}
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"Increment", "018cc251-f400-7abc-8def-0123456789ab", "018cc251-f400-7abc-8def-0123456789ac"},
+		{"Carry into high half", "018cc251-f400-7abc-ffff-ffffffffffff", "018cc251-f400-7abd-0000-000000000000"},
+		{"Nil UUID", "00000000-0000-0000-0000-000000000000", "00000000-0000-0000-0000-000000000001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ldid, _ := Parse(tt.input)
+
+			next, err := ldid.Next()
+			if err != nil {
+				t.Fatalf("Next() error = %v, wantErr %v", err, false)
+			}
+
+			if next.String() != tt.want {
+				t.Fatalf("Next() = %v, want %v", next, tt.want)
+			}
+		})
+	}
+
+	t.Run("Overflow", func(t *testing.T) {
+		ldid, _ := Parse("ffffffff-ffff-ffff-ffff-ffffffffffff")
+
+		if _, err := ldid.Next(); err == nil {
+			t.Fatalf("Next() error = %v, wantErr true", err)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, err := (*LDID)(nil).Next(); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("Next() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestPrev(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"Decrement", "018cc251-f400-7abc-8def-0123456789ab", "018cc251-f400-7abc-8def-0123456789aa"},
+		{"Borrow from high half", "018cc251-f400-7abd-0000-000000000000", "018cc251-f400-7abc-ffff-ffffffffffff"},
+		{"Max UUID", "ffffffff-ffff-ffff-ffff-ffffffffffff", "ffffffff-ffff-ffff-ffff-fffffffffffe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ldid, _ := Parse(tt.input)
+
+			prev, err := ldid.Prev()
+			if err != nil {
+				t.Fatalf("Prev() error = %v, wantErr %v", err, false)
+			}
+
+			if prev.String() != tt.want {
+				t.Fatalf("Prev() = %v, want %v", prev, tt.want)
+			}
+
+			if next, _ := prev.Next(); next.String() != tt.input {
+				t.Fatalf("Prev().Next() = %v, want %v", next, tt.input)
+			}
+		})
+	}
+
+	t.Run("Underflow", func(t *testing.T) {
+		ldid, _ := Parse("00000000-0000-0000-0000-000000000000")
+
+		if _, err := ldid.Prev(); err == nil {
+			t.Fatalf("Prev() error = %v, wantErr true", err)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, err := (*LDID)(nil).Prev(); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("Prev() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestFloorCeilID(t *testing.T) {
+	exact := time.Date(2024, time.January, 2, 3, 4, 5, 6000000, time.UTC) // 0x018cc820d88e
+	between := exact.Add(300 * time.Microsecond)
+
+	tests := []struct {
+		name  string
+		t     time.Time
+		floor string
+		ceil  string
+	}{
+		{"Exact millisecond", exact, "018cc820-d88e-0000-0000-000000000000", "018cc820-d88e-ffff-ffff-ffffffffffff"},
+		{"Between milliseconds", between, "018cc820-d88e-0000-0000-000000000000", "018cc820-d88f-ffff-ffff-ffffffffffff"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if floor := FloorID(tt.t); floor.String() != tt.floor {
+				t.Fatalf("FloorID() = %v, want %v", floor, tt.floor)
+			}
+
+			if ceil := CeilID(tt.t); ceil.String() != tt.ceil {
+				t.Fatalf("CeilID() = %v, want %v", ceil, tt.ceil)
+			}
+		})
+	}
+
+	t.Run("Bounds LDIDs of the millisecond", func(t *testing.T) {
+		ids := Corpus(1, 100, exact, 0)
+
+		for _, ldid := range ids {
+			if c, _ := Compare(FloorID(exact), ldid); c > 0 {
+				t.Fatalf("FloorID() = %v, want at most %v", FloorID(exact), ldid)
+			}
+
+			if c, _ := Compare(CeilID(exact), ldid); c < 0 {
+				t.Fatalf("CeilID() = %v, want at least %v", CeilID(exact), ldid)
+			}
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package id
+
+import (
+	"errors"
+	"io"
+)
+
+// Compile-time check to ensure SaltedGenerator implements TimeSource and BulkGenerator
+var (
+	_ TimeSource    = &SaltedGenerator{}
+	_ BulkGenerator = &SaltedGenerator{}
+)
+
+// SaltedGenerator wraps a Generator and XORs a fixed per-instance Salt into RandB, so that
+// LDIDs from deployments with different salts can be told apart by someone who knows the
+// salts, and the random data of identically seeded deployments differs. Use WithoutSalt to
+// recover the RandB produced by the wrapped generator.
+//
+// The salt is applied by the constructors of this package, which know which field is RandB,
+// including NewWithLayout for layouts with a RandB of another size. Only the low bits of Salt
+// that fit RandB are used, 62 with the default layout.
+//
+// Salting is obfuscation, not security: the salt is recoverable from any LDID whose unsalted
+// RandB is known, and it adds no entropy.
+type SaltedGenerator struct {
+	Generator Generator // Wrapped generator; the default generator when nil.
+	Salt      uint64    // Salt XORed into RandB.
+}
+
+// NowMillis returns the time of the wrapped generator, including the errors of its TimeSource
+// if it implements one.
+func (g *SaltedGenerator) NowMillis() (uint64, error) {
+	if ts, ok := g.generator().(TimeSource); ok {
+		return ts.NowMillis()
+	}
+
+	return g.generator().GenerateUnixTimestampMS(), nil
+}
+
+func (g *SaltedGenerator) GenerateUnixTimestampMS() uint64 {
+	return g.generator().GenerateUnixTimestampMS()
+}
+
+// GenerateRandomBits returns the random bits of the wrapped generator. The salt is not applied
+// here but by the constructors, which XOR it into RandB once the field is known.
+func (g *SaltedGenerator) GenerateRandomBits(randReader io.Reader, n int64) (uint64, error) {
+	return g.generator().GenerateRandomBits(randReader, n)
+}
+
+// GenerateRandomBytes returns the random bytes of the wrapped generator, which must implement
+// BulkGenerator. Like GenerateRandomBits, they are not salted.
+func (g *SaltedGenerator) GenerateRandomBytes(randReader io.Reader, n int) ([]byte, error) {
+	bg, ok := g.generator().(BulkGenerator)
+	if !ok {
+		return nil, errors.New("failed to generate random bytes: wrapped generator does not implement BulkGenerator")
+	}
+
+	return bg.GenerateRandomBytes(randReader, n)
+}
+
+// randBSalt returns the salt combined with that of the wrapped generator, if it is salted too.
+func (g *SaltedGenerator) randBSalt() uint64 {
+	return saltRandB(g.generator(), g.Salt)
+}
+
+// generator returns the wrapped generator, or the default generator when none is set.
+func (g *SaltedGenerator) generator() Generator {
+	if g.Generator == nil {
+		return defaultGenerator
+	}
+	return g.Generator
+}
+
+// WithoutSalt returns a copy of the LDID with the salt of a SaltedGenerator XORed out of RandB
+// again, recovering the LDID the wrapped generator produced. It returns nil for a nil LDID.
+func (id *LDID) WithoutSalt(salt uint64) *LDID {
+	randB, err := id.RandB()
+	if err != nil {
+		return nil
+	}
+
+	unsalted := fromBytes(id.bf.Bytes())
+	unsalted.bf.InsertUint64(randBOffset, randBSize, randB^salt&(1<<randBSize-1))
+
+	return unsalted
+}
+
+// randBSalter is implemented by generators with a salt that the constructors XOR into RandB,
+// such as SaltedGenerator. Generators that wrap another one forward it.
+type randBSalter interface {
+	randBSalt() uint64
+}
+
+// saltRandB returns randB XORed with the salt of g, or randB unchanged if g is not salted. Bits
+// beyond the size of the RandB field are discarded when the field is stored.
+func saltRandB(g Generator, randB uint64) uint64 {
+	if s, ok := g.(randBSalter); ok {
+		return randB ^ s.randBSalt()
+	}
+
+	return randB
+}
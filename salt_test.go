@@ -0,0 +1,158 @@
+package id
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestSaltedGenerator(t *testing.T) {
+	const salt = 0x0123456789abcdef
+
+	t.Run("Round trip", func(t *testing.T) {
+		m := &MockGenerator{
+			GenerateRandomBitsFunc: func(randReader io.Reader, n int64) (uint64, error) {
+				return 0x2a, nil
+			},
+		}
+
+		base, _ := NewWithGenerator(m)
+
+		salted, err := NewWithGenerator(&SaltedGenerator{Generator: m, Salt: salt})
+		if err != nil {
+			t.Fatalf("NewWithGenerator() error = %v, wantErr %v", err, false)
+		}
+
+		if salted.String() == base.String() {
+			t.Fatalf("NewWithGenerator() = %v, want salted RandB", salted)
+		}
+
+		if !salted.IsValid() {
+			t.Fatalf("NewWithGenerator() = %v, want valid LDID", salted)
+		}
+
+		if randA, _ := salted.RandA(); randA != 0x2a {
+			t.Fatalf("RandA() = %#x, want %#x", randA, 0x2a)
+		}
+
+		if unsalted := salted.WithoutSalt(salt); unsalted.String() != base.String() {
+			t.Fatalf("WithoutSalt() = %v, want %v", unsalted, base)
+		}
+	})
+
+	t.Run("Default generator", func(t *testing.T) {
+		ldid, err := NewWithGenerator(&SaltedGenerator{Salt: salt})
+		if err != nil {
+			t.Fatalf("NewWithGenerator() error = %v, wantErr %v", err, false)
+		}
+
+		saltedRandB, _ := ldid.RandB()
+		expected := saltedRandB ^ salt&(1<<62-1)
+
+		if randB, _ := ldid.WithoutSalt(salt).RandB(); randB != expected {
+			t.Fatalf("WithoutSalt() RandB = %#x, want %#x", randB, expected)
+		}
+	})
+
+	t.Run("Custom layout", func(t *testing.T) {
+		// RandA is 62 bits wide in this layout, so the salt must not be applied to it
+		layout := Layout{TimestampSize: 48, RandASize: 62, RandBSize: 12}
+		m := &MockGenerator{
+			GenerateRandomBitsFunc: func(randReader io.Reader, n int64) (uint64, error) {
+				return 0, nil
+			},
+		}
+
+		ldid, err := NewWithLayout(&SaltedGenerator{Generator: m, Salt: salt}, layout)
+		if err != nil {
+			t.Fatalf("NewWithLayout() error = %v, wantErr %v", err, false)
+		}
+
+		if randA, _ := layout.RandA(ldid); randA != 0 {
+			t.Fatalf("RandA() = %#x, want %#x", randA, 0)
+		}
+
+		if randB, _ := layout.RandB(ldid); randB != salt&(1<<12-1) {
+			t.Fatalf("RandB() = %#x, want %#x", randB, salt&(1<<12-1))
+		}
+	})
+
+	t.Run("Other constructors", func(t *testing.T) {
+		m := &MockGenerator{
+			GenerateRandomBitsFunc: func(randReader io.Reader, n int64) (uint64, error) {
+				return 0, nil
+			},
+		}
+		g := &SaltedGenerator{Generator: m, Salt: salt}
+
+		f, _ := NewFactory(WithGenerator(g))
+		fromFactory, _ := f.New()
+
+		wrapped, _ := NewWithGenerator(&FaultyGenerator{Generator: g})
+
+		parent, _ := New()
+		child, _ := parent.Child(g)
+
+		for name, ldid := range map[string]*LDID{"Factory": fromFactory, "FaultyGenerator": wrapped, "Child": child} {
+			if randB, _ := ldid.RandB(); randB != salt&(1<<62-1) {
+				t.Fatalf("%s RandB() = %#x, want %#x", name, randB, salt&(1<<62-1))
+			}
+		}
+	})
+
+	t.Run("Failing time source", func(t *testing.T) {
+		mockErr := errors.New("mock error")
+		g := &SaltedGenerator{Generator: &TimeSourceGenerator{Source: &mockTimeSource{err: mockErr}}, Salt: salt}
+
+		if _, err := NewWithGenerator(g); !errors.Is(err, mockErr) {
+			t.Fatalf("NewWithGenerator() error = %v, want %v", err, mockErr)
+		}
+	})
+
+	t.Run("Batch", func(t *testing.T) {
+		r := &countingReader{r: bytes.NewReader(make([]byte, 10*randomBytesPerLDID))}
+		g := &SaltedGenerator{Generator: &DefaultGenerator{Reader: r}, Salt: salt}
+
+		ids, err := NewBatchWithGenerator(g, 10)
+		if err != nil {
+			t.Fatalf("NewBatchWithGenerator() error = %v, wantErr %v", err, false)
+		}
+
+		if r.calls != 1 {
+			t.Fatalf("Read() calls = %v, want %v", r.calls, 1)
+		}
+
+		for _, ldid := range ids {
+			if randB, _ := ldid.RandB(); randB != salt&(1<<62-1) {
+				t.Fatalf("RandB() = %#x, want %#x", randB, salt&(1<<62-1))
+			}
+		}
+	})
+
+	t.Run("Batch without bulk support", func(t *testing.T) {
+		g := &SaltedGenerator{Generator: &countingGenerator{}, Salt: salt}
+
+		if _, err := g.GenerateRandomBytes(nil, 10); err == nil {
+			t.Fatalf("GenerateRandomBytes() error = %v, wantErr %v", err, true)
+		}
+
+		if _, err := NewBatchWithGenerator(g, 10); err != nil {
+			t.Fatalf("NewBatchWithGenerator() error = %v, wantErr %v", err, false)
+		}
+	})
+
+	t.Run("Generator failing", func(t *testing.T) {
+		g := &SaltedGenerator{Generator: &FaultyGenerator{FailOnCall: 1}, Salt: salt}
+
+		if _, err := NewWithGenerator(g); !errors.Is(err, ErrInjectedFault) {
+			t.Fatalf("NewWithGenerator() error = %v, want %v", err, ErrInjectedFault)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if unsalted := (*LDID)(nil).WithoutSalt(salt); unsalted != nil {
+			t.Fatalf("WithoutSalt() = %v, want nil", unsalted)
+		}
+	})
+}
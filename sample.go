@@ -0,0 +1,60 @@
+package id
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// Reservoir keeps a uniform random sample of at most k LDIDs from a stream of unknown length,
+// using reservoir sampling: after n LDIDs have been added, each of them is in the sample with
+// probability k/n. A Reservoir is safe for concurrent use.
+type Reservoir struct {
+	mu     sync.Mutex
+	rand   *rand.Rand
+	sample []*LDID
+	seen   int
+}
+
+// NewReservoir creates a new Reservoir that samples k LDIDs, choosing them with a math/rand
+// source seeded with seed so the same stream always yields the same sample. A k below 1 is
+// treated as 1.
+func NewReservoir(k int, seed int64) *Reservoir {
+	if k < 1 {
+		k = 1
+	}
+
+	return &Reservoir{
+		rand:   rand.New(rand.NewSource(seed)),
+		sample: make([]*LDID, 0, k),
+	}
+}
+
+// Add offers the LDID to the sample. A nil LDID is ignored.
+func (r *Reservoir) Add(id *LDID) {
+	if id.isNil() {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.seen++
+
+	if len(r.sample) < cap(r.sample) {
+		r.sample = append(r.sample, id)
+		return
+	}
+
+	if i := r.rand.Intn(r.seen); i < len(r.sample) {
+		r.sample[i] = id
+	}
+}
+
+// Sample returns a copy of the current sample, which holds all added LDIDs until more than k
+// have been added and exactly k afterwards. The order of the sample is unspecified.
+func (r *Reservoir) Sample() []*LDID {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]*LDID(nil), r.sample...)
+}
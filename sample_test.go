@@ -0,0 +1,71 @@
+package id
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReservoir(t *testing.T) {
+	ids := Corpus(1, 1000, time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC), time.Millisecond)
+
+	t.Run("Fewer than k", func(t *testing.T) {
+		r := NewReservoir(10, 1)
+		for _, ldid := range ids[:5] {
+			r.Add(ldid)
+		}
+
+		if sample := r.Sample(); len(sample) != 5 {
+			t.Fatalf("Sample() length = %v, want %v", len(sample), 5)
+		}
+	})
+
+	t.Run("More than k", func(t *testing.T) {
+		r := NewReservoir(10, 1)
+		for _, ldid := range ids {
+			r.Add(ldid)
+		}
+
+		sample := r.Sample()
+		if len(sample) != 10 {
+			t.Fatalf("Sample() length = %v, want %v", len(sample), 10)
+		}
+
+		// A uniform sample of 10 out of 1000 is very unlikely to consist of the first 10
+		late := 0
+		for _, ldid := range sample {
+			for _, other := range ids[10:] {
+				if ldid == other {
+					late++
+				}
+			}
+		}
+
+		if late == 0 {
+			t.Fatalf("Sample() = %v, want LDIDs added after the first %v", sample, 10)
+		}
+	})
+
+	t.Run("Reproducible", func(t *testing.T) {
+		a, b := NewReservoir(10, 42), NewReservoir(10, 42)
+		for _, ldid := range ids {
+			a.Add(ldid)
+			b.Add(ldid)
+		}
+
+		sampleA, sampleB := a.Sample(), b.Sample()
+		for i := range sampleA {
+			if sampleA[i] != sampleB[i] {
+				t.Fatalf("Sample()[%d] = %v, want %v", i, sampleB[i], sampleA[i])
+			}
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		r := NewReservoir(0, 1)
+		r.Add(nil)
+
+		if sample := r.Sample(); len(sample) != 0 {
+			t.Fatalf("Sample() length = %v, want %v", len(sample), 0)
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package id
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSecondsLayout(t *testing.T) {
+	if err := SecondsLayout.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v, wantErr %v", err, false)
+	}
+}
+
+func TestSecondsGenerator(t *testing.T) {
+	t.Run("Time reads back at second resolution", func(t *testing.T) {
+		now := time.Date(2024, time.January, 2, 3, 4, 5, 999_000_000, time.UTC)
+		g := &SecondsGenerator{Clock: func() time.Time { return now }}
+
+		ldid, err := NewWithLayout(g, SecondsLayout)
+		if err != nil {
+			t.Fatalf("NewWithLayout() error = %v, wantErr %v", err, false)
+		}
+
+		expected := now.Truncate(time.Second)
+		if got, _ := ldid.SecondsTime(); !got.Equal(expected) {
+			t.Fatalf("SecondsTime() = %v, want %v", got, expected)
+		}
+
+		if version, _ := SecondsLayout.Version(ldid); version != 0b0111 {
+			t.Fatalf("Version() = %v, want %v", version, 0b0111)
+		}
+
+		if variant, _ := SecondsLayout.Variant(ldid); variant != 0b10 {
+			t.Fatalf("Variant() = %v, want %v", variant, 0b10)
+		}
+	})
+
+	t.Run("Sorts by second", func(t *testing.T) {
+		earlier, _ := NewWithLayout(&SecondsGenerator{Clock: func() time.Time { return time.Unix(1704164645, 0) }}, SecondsLayout)
+		later, _ := NewWithLayout(&SecondsGenerator{Clock: func() time.Time { return time.Unix(1704164646, 0) }}, SecondsLayout)
+
+		if c, _ := Compare(earlier, later); c != -1 {
+			t.Fatalf("Compare() = %v, want %v", c, -1)
+		}
+	})
+
+	t.Run("Current time", func(t *testing.T) {
+		before := time.Now().Truncate(time.Second)
+
+		ldid, err := NewSeconds()
+		if err != nil {
+			t.Fatalf("NewSeconds() error = %v, wantErr %v", err, false)
+		}
+
+		if got, _ := ldid.SecondsTime(); got.Before(before) || got.After(time.Now()) {
+			t.Fatalf("SecondsTime() = %v, want between %v and now", got, before)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, err := (*LDID)(nil).SecondsTime(); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("SecondsTime() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
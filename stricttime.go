@@ -0,0 +1,83 @@
+package id
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// ErrTimestampUsed is returned by a StrictTimeGenerator that does not wait when the current
+// millisecond has already been used for an LDID.
+var ErrTimestampUsed = errors.New("timestamp already used")
+
+// Compile-time check to ensure StrictTimeGenerator implements TimeSource
+var _ TimeSource = &StrictTimeGenerator{}
+
+// StrictTimeGenerator is a Generator that gives every LDID its own millisecond, so timestamps
+// strictly increase across all LDIDs created with it, e.g. for a single-writer log. When the
+// current millisecond has already been used, it waits for the next one if Wait is set, and
+// returns ErrTimestampUsed otherwise.
+//
+// This limits throughput to 1000 LDIDs per second per generator. Waiting callers are served one
+// at a time, and after the clock steps backward they wait until it passes the last timestamp
+// again. A StrictTimeGenerator is safe for concurrent use.
+type StrictTimeGenerator struct {
+	// Clock returns the current time. When nil, time.Now is used.
+	Clock func() time.Time
+	// Reader is the source of random data. When nil, crypto/rand.Reader is used.
+	Reader io.Reader
+	// Wait makes the generator wait for an unused millisecond instead of failing.
+	Wait bool
+
+	mu   sync.Mutex
+	last uint64
+}
+
+func (g *StrictTimeGenerator) now() time.Time {
+	if g.Clock != nil {
+		return g.Clock()
+	}
+	return time.Now()
+}
+
+// NowMillis returns the current time in Unix milliseconds if no LDID has used it or a later
+// timestamp yet, waiting for such a time if Wait is set.
+func (g *StrictTimeGenerator) NowMillis() (uint64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	t := g.now()
+	ms := uint64(t.UnixMilli())
+
+	for ms <= g.last {
+		if !g.Wait {
+			return 0, fmt.Errorf("%w: %d", ErrTimestampUsed, ms)
+		}
+
+		time.Sleep(time.UnixMilli(int64(g.last + 1)).Sub(t))
+
+		t = g.now()
+		ms = uint64(t.UnixMilli())
+	}
+
+	g.last = ms
+
+	return ms, nil
+}
+
+// GenerateUnixTimestampMS returns the result of NowMillis, or 0 if it fails. The constructors
+// of this package call NowMillis instead, so they can return the error.
+func (g *StrictTimeGenerator) GenerateUnixTimestampMS() uint64 {
+	timestamp, err := g.NowMillis()
+	if err != nil {
+		return 0
+	}
+
+	return timestamp
+}
+
+func (g *StrictTimeGenerator) GenerateRandomBits(randReader io.Reader, n int64) (uint64, error) {
+	return (&DefaultGenerator{Reader: g.Reader}).GenerateRandomBits(randReader, n)
+}
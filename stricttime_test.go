@@ -0,0 +1,72 @@
+package id
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStrictTimeGenerator(t *testing.T) {
+	t.Run("Wait gives distinct timestamps", func(t *testing.T) {
+		g := &StrictTimeGenerator{Wait: true}
+
+		var previous uint64
+		for i := 0; i < 20; i++ {
+			ldid, err := NewWithGenerator(g)
+			if err != nil {
+				t.Fatalf("NewWithGenerator() error = %v, wantErr %v", err, false)
+			}
+
+			timestamp, _ := ldid.Timestamp()
+			if i > 0 && timestamp <= previous {
+				t.Fatalf("Timestamp() = %v after %v, want strictly increasing", timestamp, previous)
+			}
+			previous = timestamp
+		}
+	})
+
+	t.Run("Wait with injected clock", func(t *testing.T) {
+		// Every call advances the clock by 300µs, so several calls share a millisecond
+		now := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+		g := &StrictTimeGenerator{
+			Clock: func() time.Time {
+				now = now.Add(300 * time.Microsecond)
+				return now
+			},
+			Wait: true,
+		}
+
+		var previous uint64
+		for i := 0; i < 10; i++ {
+			timestamp, err := g.NowMillis()
+			if err != nil {
+				t.Fatalf("NowMillis() error = %v, wantErr %v", err, false)
+			}
+
+			if i > 0 && timestamp <= previous {
+				t.Fatalf("NowMillis() = %v after %v, want strictly increasing", timestamp, previous)
+			}
+			previous = timestamp
+		}
+	})
+
+	t.Run("Error mode", func(t *testing.T) {
+		g := &StrictTimeGenerator{
+			Clock: func() time.Time {
+				return time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+			},
+		}
+
+		if _, err := NewWithGenerator(g); err != nil {
+			t.Fatalf("NewWithGenerator() error = %v, wantErr %v", err, false)
+		}
+
+		if _, err := NewWithGenerator(g); !errors.Is(err, ErrTimestampUsed) {
+			t.Fatalf("NewWithGenerator() error = %v, want %v", err, ErrTimestampUsed)
+		}
+
+		if timestamp := g.GenerateUnixTimestampMS(); timestamp != 0 {
+			t.Fatalf("GenerateUnixTimestampMS() = %v, want %v", timestamp, 0)
+		}
+	})
+}
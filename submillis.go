@@ -0,0 +1,59 @@
+package id
+
+import (
+	"io"
+	"time"
+)
+
+// SubMillisecondGenerator is a Generator that stores the fraction of the millisecond in all
+// 12 bits of RandA, following RFC 9562 section 6.2 method 3. The fraction is scaled to 4096
+// steps, so the time is encoded with a precision of 1ms/4096, about 244ns, and LDIDs created
+// within the same millisecond sort by that fraction. 62 random bits remain per LDID.
+//
+// The fraction is taken from the time read by the preceding GenerateUnixTimestampMS call.
+// A SubMillisecondGenerator is not safe for concurrent use: a call from another goroutine in
+// between would give the LDID the fraction of a different clock read, possibly from a
+// different millisecond. Use a generator per goroutine. Use SubMillis to read the fraction
+// back.
+type SubMillisecondGenerator struct {
+	// Clock returns the current time. When nil, time.Now is used.
+	Clock func() time.Time
+	// Reader is the source of random data. When nil, crypto/rand.Reader is used.
+	Reader io.Reader
+
+	last time.Time
+}
+
+func (g *SubMillisecondGenerator) GenerateUnixTimestampMS() uint64 {
+	now := time.Now
+	if g.Clock != nil {
+		now = g.Clock
+	}
+
+	g.last = now()
+
+	return uint64(g.last.UnixMilli())
+}
+
+func (g *SubMillisecondGenerator) GenerateRandomBits(randReader io.Reader, n int64) (uint64, error) {
+	if n != int64(randASize) {
+		d := &DefaultGenerator{Reader: g.Reader}
+		return d.GenerateRandomBits(randReader, n)
+	}
+
+	frac := uint64(g.last.Nanosecond() % int(time.Millisecond))
+
+	return frac << randASize / uint64(time.Millisecond), nil
+}
+
+// SubMillis returns the offset within the millisecond encoded in RandA by a
+// SubMillisecondGenerator, rounded down to a multiple of about 244ns. For LDIDs from other
+// generators RandA is random and the result is meaningless.
+func (id *LDID) SubMillis() (time.Duration, error) {
+	randA, err := id.RandA()
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(randA * uint64(time.Millisecond) >> randASize), nil
+}
@@ -0,0 +1,80 @@
+package id
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSubMillisecondGenerator(t *testing.T) {
+	t.Run("Round trip", func(t *testing.T) {
+		for _, offset := range []time.Duration{0, 244, 123456, 500 * time.Microsecond, 999999} {
+			ts := time.Date(2024, time.January, 2, 3, 4, 5, 6000000, time.UTC).Add(offset)
+			g := &SubMillisecondGenerator{
+				Clock: func() time.Time {
+					return ts
+				},
+			}
+
+			ldid, err := NewWithGenerator(g)
+			if err != nil {
+				t.Fatalf("NewWithGenerator() error = %v, wantErr %v", err, false)
+			}
+
+			got, err := ldid.SubMillis()
+			if err != nil {
+				t.Fatalf("SubMillis() error = %v, wantErr %v", err, false)
+			}
+
+			// The fraction is rounded down to 1ms/4096 in both directions
+			if got > offset || offset-got > 2*time.Millisecond/4096 {
+				t.Fatalf("SubMillis() = %v, want within 488ns below %v", got, offset)
+			}
+
+			if tm, _ := ldid.Time(); !tm.Equal(ts.Truncate(time.Millisecond)) {
+				t.Fatalf("Time() = %v, want %v", tm, ts.Truncate(time.Millisecond))
+			}
+		}
+	})
+
+	t.Run("Rapid successive calls sort in order", func(t *testing.T) {
+		ts := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+		g := &SubMillisecondGenerator{
+			Clock: func() time.Time {
+				ts = ts.Add(250 * time.Nanosecond)
+				return ts
+			},
+		}
+
+		var previous *LDID
+		for i := 0; i < 10000; i++ {
+			ldid, err := NewWithGenerator(g)
+			if err != nil {
+				t.Fatalf("NewWithGenerator() error = %v, wantErr %v", err, false)
+			}
+
+			if previous != nil && bytes.Compare(previous.Bytes(), ldid.Bytes()) >= 0 {
+				t.Fatalf("NewWithGenerator() = %v after %v, want ascending order", ldid, previous)
+			}
+			previous = ldid
+		}
+	})
+
+	t.Run("Other fields", func(t *testing.T) {
+		ldid, err := NewWithGenerator(&SubMillisecondGenerator{})
+		if err != nil {
+			t.Fatalf("NewWithGenerator() error = %v, wantErr %v", err, false)
+		}
+
+		if err := ldid.validate(); err != nil {
+			t.Fatalf("NewWithGenerator() = %v, want valid LDID: %v", ldid, err)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, err := (*LDID)(nil).SubMillis(); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("SubMillis() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
@@ -0,0 +1,147 @@
+package id
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// TestID creates a fully deterministic LDID for use in tests. The timestamp field holds t
+// in Unix milliseconds, RandA holds the low 12 bits of counter and RandB is zero.
+//
+// TestID is a testing helper: the resulting IDs are predictable and must not be used in production.
+func TestID(t time.Time, counter uint16) *LDID {
+	return newFromFields(uint64(t.UnixMilli()), uint64(counter), 0)
+}
+
+// Corpus creates n deterministic LDIDs with timestamps start, start+step, start+2*step and so
+// on, and random data from a math/rand source seeded with seed. The same arguments always
+// produce the same LDIDs, which are in ascending order for a step of at least one millisecond.
+// It returns nil if n is not positive.
+//
+// Corpus is a testing helper for benchmarks and snapshot tests: the resulting IDs are
+// predictable and must not be used in production.
+func Corpus(seed int64, n int, start time.Time, step time.Duration) []*LDID {
+	if n <= 0 {
+		return nil
+	}
+
+	r := rand.New(rand.NewSource(seed))
+	ids := make([]*LDID, n)
+
+	for i := range ids {
+		t := start.Add(time.Duration(i) * step)
+		ids[i] = newFromFields(uint64(t.UnixMilli()), r.Uint64(), r.Uint64())
+	}
+
+	return ids
+}
+
+// FaultOperation selects the Generator operation a FaultyGenerator injects faults into.
+type FaultOperation int
+
+const (
+	FaultRandomBits FaultOperation = iota // Fail GenerateRandomBits with an error.
+	FaultTimestamp                        // Make GenerateUnixTimestampMS and NowMillis return 0.
+)
+
+// ErrInjectedFault is the default error returned by a FaultyGenerator.
+var ErrInjectedFault = errors.New("injected fault")
+
+// FaultyGenerator wraps a Generator and injects faults into one of its operations, to test
+// error handling around New and NewWithGenerator.
+//
+// A fault is injected on the FailOnCall-th call of the selected operation, and additionally on
+// any call with probability FailProbability. Every call of the selected operation is delayed by
+// Delay. As GenerateUnixTimestampMS cannot return an error, timestamp faults make it return 0.
+//
+// FaultyGenerator is a testing utility and is safe for concurrent use.
+type FaultyGenerator struct {
+	Generator       Generator      // Wrapped generator; the default generator when nil.
+	Operation       FaultOperation // Operation to inject faults into.
+	FailOnCall      int            // 1-based call of the operation to fail; 0 disables.
+	FailProbability float64        // Probability in [0, 1] of failing any call.
+	Delay           time.Duration  // Delay added to every call of the operation.
+	Err             error          // Error to return; ErrInjectedFault when nil.
+	Rand            *rand.Rand     // Source for FailProbability; math/rand when nil.
+
+	mu    sync.Mutex
+	calls int
+}
+
+// Compile-time check to ensure FaultyGenerator implements TimeSource
+var _ TimeSource = &FaultyGenerator{}
+
+// NowMillis returns 0 on a timestamp fault, and otherwise the time of the wrapped generator,
+// including the errors of its TimeSource if it implements one.
+func (g *FaultyGenerator) NowMillis() (uint64, error) {
+	if g.Operation == FaultTimestamp && g.fault() {
+		return 0, nil
+	}
+
+	if ts, ok := g.generator().(TimeSource); ok {
+		return ts.NowMillis()
+	}
+
+	return g.generator().GenerateUnixTimestampMS(), nil
+}
+
+func (g *FaultyGenerator) GenerateUnixTimestampMS() uint64 {
+	if g.Operation == FaultTimestamp && g.fault() {
+		return 0
+	}
+
+	return g.generator().GenerateUnixTimestampMS()
+}
+
+func (g *FaultyGenerator) GenerateRandomBits(randReader io.Reader, n int64) (uint64, error) {
+	if g.Operation == FaultRandomBits && g.fault() {
+		if g.Err != nil {
+			return 0, g.Err
+		}
+		return 0, ErrInjectedFault
+	}
+
+	return g.generator().GenerateRandomBits(randReader, n)
+}
+
+// randBSalt forwards the salt of the wrapped generator, if it is salted.
+func (g *FaultyGenerator) randBSalt() uint64 {
+	return saltRandB(g.generator(), 0)
+}
+
+// generator returns the wrapped generator, or the default generator when none is set.
+func (g *FaultyGenerator) generator() Generator {
+	if g.Generator == nil {
+		return defaultGenerator
+	}
+	return g.Generator
+}
+
+// fault counts a call of the faulty operation, applies the delay and reports whether the call
+// should fail.
+func (g *FaultyGenerator) fault() bool {
+	if g.Delay > 0 {
+		time.Sleep(g.Delay)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.calls++
+
+	if g.FailOnCall > 0 && g.calls == g.FailOnCall {
+		return true
+	}
+
+	if g.FailProbability > 0 {
+		if g.Rand != nil {
+			return g.Rand.Float64() < g.FailProbability
+		}
+		return rand.Float64() < g.FailProbability
+	}
+
+	return false
+}
@@ -0,0 +1,167 @@
+package id
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestTestID(t *testing.T) {
+	ts := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("Fields", func(t *testing.T) {
+		ldid := TestID(ts, 42)
+
+		if timestamp, _ := ldid.Timestamp(); timestamp != uint64(ts.UnixMilli()) {
+			t.Fatalf("Timestamp() = %v, want %v", timestamp, ts.UnixMilli())
+		}
+
+		if randA, _ := ldid.RandA(); randA != 42 {
+			t.Fatalf("RandA() = %v, want %v", randA, 42)
+		}
+
+		if randB, _ := ldid.RandB(); randB != 0 {
+			t.Fatalf("RandB() = %v, want %v", randB, 0)
+		}
+
+		if version, _ := ldid.Version(); version != 0b0111 {
+			t.Fatalf("Version() = %v, want %v", version, 0b0111)
+		}
+
+		if variant, _ := ldid.Variant(); variant != 0b10 {
+			t.Fatalf("Variant() = %v, want %v", variant, 0b10)
+		}
+	})
+
+	t.Run("Deterministic", func(t *testing.T) {
+		if a, b := TestID(ts, 1), TestID(ts, 1); a.String() != b.String() {
+			t.Fatalf("TestID() = %v and %v, want equal", a, b)
+		}
+	})
+}
+
+func TestCorpus(t *testing.T) {
+	start := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("Deterministic", func(t *testing.T) {
+		a := Corpus(42, 100, start, time.Second)
+		b := Corpus(42, 100, start, time.Second)
+
+		if len(a) != 100 || len(b) != 100 {
+			t.Fatalf("Corpus() len = %v and %v, want %v", len(a), len(b), 100)
+		}
+
+		for i := range a {
+			if a[i].String() != b[i].String() {
+				t.Fatalf("Corpus()[%d] = %v and %v, want equal", i, a[i], b[i])
+			}
+		}
+
+		if c := Corpus(43, 100, start, time.Second); c[0].String() == a[0].String() {
+			t.Fatalf("Corpus() with different seed = %v, want different from %v", c[0], a[0])
+		}
+	})
+
+	t.Run("Timestamps and order", func(t *testing.T) {
+		ids := Corpus(1, 50, start, time.Millisecond)
+
+		for i, ldid := range ids {
+			expected := start.Add(time.Duration(i) * time.Millisecond)
+			if tm, _ := ldid.Time(); !tm.Equal(expected) {
+				t.Fatalf("Corpus()[%d].Time() = %v, want %v", i, tm, expected)
+			}
+
+			if err := ldid.validate(); err != nil {
+				t.Fatalf("Corpus()[%d] = %v, want valid LDID: %v", i, ldid, err)
+			}
+
+			if i > 0 {
+				if c, _ := Compare(ids[i-1], ldid); c >= 0 {
+					t.Fatalf("Corpus()[%d] = %v after %v, want ascending order", i, ldid, ids[i-1])
+				}
+			}
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		if ids := Corpus(1, 0, start, time.Second); ids != nil {
+			t.Fatalf("Corpus() = %v, want nil", ids)
+		}
+	})
+}
+
+func TestFaultyGenerator(t *testing.T) {
+	t.Run("Nth New fails", func(t *testing.T) {
+		// Each New makes two GenerateRandomBits calls, so call 5 is the first of the 3rd New
+		g := &FaultyGenerator{FailOnCall: 5}
+
+		for i := 1; i <= 4; i++ {
+			_, err := NewWithGenerator(g)
+
+			if wantErr := i == 3; (err != nil) != wantErr {
+				t.Fatalf("NewWithGenerator() call %d error = %v, wantErr %v", i, err, wantErr)
+			}
+
+			if i == 3 && !errors.Is(err, ErrInjectedFault) {
+				t.Fatalf("NewWithGenerator() error = %v, want %v", err, ErrInjectedFault)
+			}
+		}
+	})
+
+	t.Run("Custom error", func(t *testing.T) {
+		mockErr := errors.New("mock error")
+		g := &FaultyGenerator{FailOnCall: 1, Err: mockErr}
+
+		if _, err := NewWithGenerator(g); !errors.Is(err, mockErr) {
+			t.Fatalf("NewWithGenerator() error = %v, want %v", err, mockErr)
+		}
+	})
+
+	t.Run("Timestamp fault", func(t *testing.T) {
+		g := &FaultyGenerator{Operation: FaultTimestamp, FailOnCall: 1}
+
+		ldid, err := NewWithGenerator(g)
+		if err != nil {
+			t.Fatalf("NewWithGenerator() error = %v, wantErr %v", err, false)
+		}
+
+		if timestamp, _ := ldid.Timestamp(); timestamp != 0 {
+			t.Fatalf("Timestamp() = %v, want %v", timestamp, 0)
+		}
+	})
+
+	t.Run("Failing time source", func(t *testing.T) {
+		mockErr := errors.New("mock error")
+		g := &FaultyGenerator{Generator: &TimeSourceGenerator{Source: &mockTimeSource{err: mockErr}}}
+
+		if _, err := NewWithGenerator(g); !errors.Is(err, mockErr) {
+			t.Fatalf("NewWithGenerator() error = %v, want %v", err, mockErr)
+		}
+	})
+
+	t.Run("Probability", func(t *testing.T) {
+		always := &FaultyGenerator{FailProbability: 1, Rand: rand.New(rand.NewSource(1))}
+		if _, err := NewWithGenerator(always); err == nil {
+			t.Fatalf("NewWithGenerator() error = %v, wantErr true", err)
+		}
+
+		never := &FaultyGenerator{FailProbability: 0}
+		if _, err := NewWithGenerator(never); err != nil {
+			t.Fatalf("NewWithGenerator() error = %v, wantErr %v", err, false)
+		}
+	})
+
+	t.Run("Delay", func(t *testing.T) {
+		g := &FaultyGenerator{Delay: 5 * time.Millisecond}
+
+		start := time.Now()
+		if _, err := NewWithGenerator(g); err != nil {
+			t.Fatalf("NewWithGenerator() error = %v, wantErr %v", err, false)
+		}
+
+		if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+			t.Fatalf("NewWithGenerator() took %v, want at least %v", elapsed, 10*time.Millisecond)
+		}
+	})
+}
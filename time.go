@@ -0,0 +1,214 @@
+package id
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Time returns the timestamp of the LDID as a time.Time in UTC with millisecond precision.
+func (id *LDID) Time() (time.Time, error) {
+	timestamp, err := id.Timestamp()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.UnixMilli(int64(timestamp)).UTC(), nil
+}
+
+// GroupByWindow groups LDIDs by the start of the time window their timestamp falls in.
+// Window starts are computed with time.Time.Truncate and are in UTC.
+func GroupByWindow(ids []*LDID, window time.Duration) (map[time.Time][]*LDID, error) {
+	if window <= 0 {
+		return nil, errors.New("failed to group LDIDs: window must be positive")
+	}
+
+	groups := make(map[time.Time][]*LDID)
+
+	for _, id := range ids {
+		t, err := id.Time()
+		if err != nil {
+			return nil, err
+		}
+
+		start := t.Truncate(window)
+		groups[start] = append(groups[start], id)
+	}
+
+	return groups, nil
+}
+
+// Elapsed returns the time between the timestamps of two LDIDs (to - from) with millisecond
+// precision. The duration is negative when from is later than to.
+func Elapsed(from, to *LDID) (time.Duration, error) {
+	start, err := from.Timestamp()
+	if err != nil {
+		return 0, err
+	}
+
+	end, err := to.Timestamp()
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(int64(end)-int64(start)) * time.Millisecond, nil
+}
+
+// PositionInRange reports where the timestamp of the LDID falls relative to the inclusive
+// range [start, end]: -1 if before start, 0 if within the range and 1 if after end. The
+// timestamp has millisecond precision, so an LDID created in the same millisecond as end but
+// after it is still within the range when end is a whole millisecond.
+func (id *LDID) PositionInRange(start, end time.Time) (int, error) {
+	if end.Before(start) {
+		return 0, errors.New("failed to compare LDID to range: end is before start")
+	}
+
+	t, err := id.Time()
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case t.Before(start):
+		return -1, nil
+	case t.After(end):
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// TimestampSpread returns the earliest and latest timestamps among the LDIDs, e.g. to check
+// that a batch was generated within an expected window. It returns an error for an empty slice.
+func TimestampSpread(ids []*LDID) (min, max time.Time, err error) {
+	if len(ids) == 0 {
+		return time.Time{}, time.Time{}, errors.New("failed to compute timestamp spread: no LDIDs")
+	}
+
+	for i, id := range ids {
+		t, err := id.Time()
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+
+		if i == 0 || t.Before(min) {
+			min = t
+		}
+
+		if i == 0 || t.After(max) {
+			max = t
+		}
+	}
+
+	return min, max, nil
+}
+
+// GenerationRate estimates how many LDIDs per second were generated from a slice sorted by
+// creation, e.g. a sample from a producer: n LDIDs whose timestamps span d contain n-1 intervals,
+// so the rate is (n-1)/d. Only the first and last LDID are read. It returns an error for fewer
+// than two LDIDs or a span that is not positive, which includes all LDIDs sharing one
+// millisecond, as no rate can be derived from them.
+func GenerationRate(ids []*LDID) (float64, error) {
+	if len(ids) < 2 {
+		return 0, fmt.Errorf("failed to compute generation rate: need at least 2 LDIDs, got %d", len(ids))
+	}
+
+	span, err := Elapsed(ids[0], ids[len(ids)-1])
+	if err != nil {
+		return 0, err
+	}
+
+	if span <= 0 {
+		return 0, fmt.Errorf("failed to compute generation rate: span %v is not positive", span)
+	}
+
+	return float64(len(ids)-1) / span.Seconds(), nil
+}
+
+// Hour returns the timestamp of the LDID truncated to the start of its hour, in UTC. Hours are
+// always UTC hours, also in time zones with a non-whole-hour offset or around daylight saving
+// time changes; convert the result with In for display only.
+func (id *LDID) Hour() (time.Time, error) {
+	t, err := id.Time()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return t.Truncate(time.Hour), nil
+}
+
+// DateString returns the UTC date of the timestamp of the LDID in the form 2006-01-02, e.g. for
+// date-partitioned storage paths. The date is always the UTC date, which differs from the local
+// date for LDIDs created near midnight in other time zones.
+func (id *LDID) DateString() (string, error) {
+	t, err := id.Time()
+	if err != nil {
+		return "", err
+	}
+
+	return t.Format(time.DateOnly), nil
+}
+
+// PartitionPath returns a storage path for the LDID, such as an object storage key: its UTC
+// timestamp formatted with a Go time layout, then a slash and the canonical string. The layout
+// uses the reference time of the time package, so "2006/01/02/15" yields paths like
+// 2024/01/02/03/018cc820-d888-7abc-8000-00000000002a. A trailing slash in the layout is
+// ignored. It returns an error for an empty layout.
+func (id *LDID) PartitionPath(layout string) (string, error) {
+	if layout == "" {
+		return "", errors.New("failed to build partition path: empty layout")
+	}
+
+	t, err := id.Time()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(t.Format(layout), "/") + "/" + id.String(), nil
+}
+
+// PredatesCutover reports whether the LDID was created before cutover, e.g. the start of a
+// schema migration. An LDID created at the cutover does not predate it. The timestamp has
+// millisecond precision, so LDIDs from the millisecond of a cutover between milliseconds
+// predate it.
+func (id *LDID) PredatesCutover(cutover time.Time) (bool, error) {
+	t, err := id.Time()
+	if err != nil {
+		return false, err
+	}
+
+	return t.Before(cutover), nil
+}
+
+// Expired reports whether more than ttl has passed since the LDID was created, e.g. to
+// enforce a retention window for data keyed by LDID. See ExpiredAt.
+func (id *LDID) Expired(ttl time.Duration) (bool, error) {
+	return id.ExpiredAt(ttl, time.Now())
+}
+
+// ExpiredAt reports whether more than ttl passed between the creation of the LDID and now. An
+// LDID exactly ttl old has not expired yet.
+func (id *LDID) ExpiredAt(ttl time.Duration, now time.Time) (bool, error) {
+	t, err := id.Time()
+	if err != nil {
+		return false, err
+	}
+
+	return now.Sub(t) > ttl, nil
+}
+
+// ISOWeek returns the ISO 8601 year and week number of the UTC date of the timestamp of the
+// LDID, e.g. for weekly rollups. ISO weeks start on Monday and week 1 is the week containing the
+// first Thursday of the year, so the ISO year differs from the calendar year for a few days
+// around January 1: 2024-12-30 is in week 1 of 2025.
+func (id *LDID) ISOWeek() (year, week int, err error) {
+	t, err := id.Time()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	year, week = t.ISOWeek()
+
+	return year, week, nil
+}
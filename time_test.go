@@ -0,0 +1,478 @@
+package id
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTime(t *testing.T) {
+	t.Run("Known timestamp", func(t *testing.T) {
+		expected := time.Date(2024, time.January, 2, 3, 4, 5, 6000000, time.UTC)
+		ldid := TestID(expected, 0)
+
+		tm, err := ldid.Time()
+		if err != nil {
+			t.Fatalf("Time() error = %v, wantErr %v", err, false)
+		}
+
+		if !tm.Equal(expected) || tm.Location() != time.UTC {
+			t.Fatalf("Time() = %v, want %v", tm, expected)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, err := (*LDID)(nil).Time(); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("Time() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestGroupByWindow(t *testing.T) {
+	first := time.Date(2024, time.January, 2, 3, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+
+	ids := []*LDID{
+		TestID(first.Add(time.Minute), 0),
+		TestID(second.Add(30*time.Minute), 0),
+		TestID(first.Add(59*time.Minute), 0),
+		TestID(second, 0),
+		TestID(second.Add(time.Millisecond), 0),
+	}
+
+	t.Run("Two windows", func(t *testing.T) {
+		groups, err := GroupByWindow(ids, time.Hour)
+		if err != nil {
+			t.Fatalf("GroupByWindow() error = %v, wantErr %v", err, false)
+		}
+
+		if len(groups) != 2 {
+			t.Fatalf("GroupByWindow() len = %v, want %v", len(groups), 2)
+		}
+
+		if len(groups[first]) != 2 {
+			t.Fatalf("GroupByWindow()[%v] len = %v, want %v", first, len(groups[first]), 2)
+		}
+
+		if len(groups[second]) != 3 {
+			t.Fatalf("GroupByWindow()[%v] len = %v, want %v", second, len(groups[second]), 3)
+		}
+	})
+
+	t.Run("Extraction error", func(t *testing.T) {
+		if _, err := GroupByWindow([]*LDID{ids[0], nil}, time.Hour); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("GroupByWindow() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+
+	t.Run("Invalid window", func(t *testing.T) {
+		if _, err := GroupByWindow(ids, 0); err == nil {
+			t.Fatalf("GroupByWindow() error = %v, wantErr true", err)
+		}
+	})
+}
+
+func TestElapsed(t *testing.T) {
+	base := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+	request := TestID(base, 0)
+	response := TestID(base.Add(1500*time.Millisecond), 1)
+
+	tests := []struct {
+		name     string
+		from, to *LDID
+		want     time.Duration
+	}{
+		{"Positive", request, response, 1500 * time.Millisecond},
+		{"Negative", response, request, -1500 * time.Millisecond},
+		{"Zero", request, TestID(base, 2), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Elapsed(tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("Elapsed() error = %v, wantErr %v", err, false)
+			}
+
+			if got != tt.want {
+				t.Fatalf("Elapsed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("Extraction error", func(t *testing.T) {
+		if _, err := Elapsed(request, nil); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("Elapsed() error = %v, want %v", err, ErrNilLDID)
+		}
+
+		if _, err := Elapsed(nil, response); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("Elapsed() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestPositionInRange(t *testing.T) {
+	start := time.Date(2024, time.January, 2, 3, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want int
+	}{
+		{"Before", start.Add(-time.Millisecond), -1},
+		{"At start", start, 0},
+		{"Within", start.Add(30 * time.Minute), 0},
+		{"At end", end, 0},
+		{"After", end.Add(time.Millisecond), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TestID(tt.t, 0).PositionInRange(start, end)
+			if err != nil {
+				t.Fatalf("PositionInRange() error = %v, wantErr %v", err, false)
+			}
+
+			if got != tt.want {
+				t.Fatalf("PositionInRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("End before start", func(t *testing.T) {
+		if _, err := TestID(start, 0).PositionInRange(end, start); err == nil {
+			t.Fatalf("PositionInRange() error = %v, wantErr true", err)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, err := (*LDID)(nil).PositionInRange(start, end); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("PositionInRange() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestTimestampSpread(t *testing.T) {
+	start := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+	end := start.Add(250 * time.Millisecond)
+
+	t.Run("Known range", func(t *testing.T) {
+		ids := []*LDID{
+			TestID(start.Add(100*time.Millisecond), 0),
+			TestID(end, 1),
+			TestID(start, 2),
+			TestID(start.Add(200*time.Millisecond), 3),
+		}
+
+		min, max, err := TimestampSpread(ids)
+		if err != nil {
+			t.Fatalf("TimestampSpread() error = %v, wantErr %v", err, false)
+		}
+
+		if !min.Equal(start) {
+			t.Fatalf("TimestampSpread() min = %v, want %v", min, start)
+		}
+
+		if !max.Equal(end) {
+			t.Fatalf("TimestampSpread() max = %v, want %v", max, end)
+		}
+	})
+
+	t.Run("Single LDID", func(t *testing.T) {
+		min, max, err := TimestampSpread([]*LDID{TestID(start, 0)})
+		if err != nil {
+			t.Fatalf("TimestampSpread() error = %v, wantErr %v", err, false)
+		}
+
+		if !min.Equal(start) || !max.Equal(start) {
+			t.Fatalf("TimestampSpread() = %v, %v, want %v, %v", min, max, start, start)
+		}
+	})
+
+	t.Run("Empty slice", func(t *testing.T) {
+		if _, _, err := TimestampSpread(nil); err == nil {
+			t.Fatalf("TimestampSpread() error = %v, wantErr true", err)
+		}
+	})
+
+	t.Run("Extraction error", func(t *testing.T) {
+		if _, _, err := TimestampSpread([]*LDID{TestID(start, 0), nil}); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("TimestampSpread() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestGenerationRate(t *testing.T) {
+	start := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("Known span", func(t *testing.T) {
+		// 501 LDIDs 4ms apart span 2 seconds
+		rate, err := GenerationRate(Corpus(1, 501, start, 4*time.Millisecond))
+		if err != nil {
+			t.Fatalf("GenerationRate() error = %v, wantErr %v", err, false)
+		}
+
+		if rate != 250 {
+			t.Fatalf("GenerationRate() = %v, want %v", rate, 250)
+		}
+	})
+
+	tests := []struct {
+		name string
+		ids  []*LDID
+		err  error
+	}{
+		{"Empty slice", nil, nil},
+		{"Single LDID", []*LDID{TestID(start, 0)}, nil},
+		{"Zero span", []*LDID{TestID(start, 0), TestID(start, 1)}, nil},
+		{"Unsorted", []*LDID{TestID(start.Add(time.Second), 0), TestID(start, 1)}, nil},
+		{"Nil LDID", []*LDID{TestID(start, 0), nil}, ErrNilLDID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := GenerationRate(tt.ids)
+			if err == nil {
+				t.Fatalf("GenerationRate() error = %v, wantErr %v", err, true)
+			}
+
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Fatalf("GenerationRate() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestHour(t *testing.T) {
+	t.Run("UTC truncation", func(t *testing.T) {
+		// In India (UTC+5:30) local hours start at half past the UTC hour
+		india := time.FixedZone("IST", 5*60*60+30*60)
+		ts := time.Date(2024, time.January, 2, 9, 10, 0, 0, india) // 03:40 UTC
+
+		hour, err := TestID(ts, 0).Hour()
+		if err != nil {
+			t.Fatalf("Hour() error = %v, wantErr %v", err, false)
+		}
+
+		expected := time.Date(2024, time.January, 2, 3, 0, 0, 0, time.UTC)
+		if !hour.Equal(expected) || hour.Location() != time.UTC {
+			t.Fatalf("Hour() = %v, want %v", hour, expected)
+		}
+	})
+
+	t.Run("Daylight saving time boundary", func(t *testing.T) {
+		newYork, err := time.LoadLocation("America/New_York")
+		if err != nil {
+			t.Skipf("time zone data unavailable: %v", err)
+		}
+
+		// Clocks in New York jumped from 02:00 EST to 03:00 EDT on 2024-03-10, at 07:00 UTC
+		tests := []struct {
+			local    time.Time
+			expected time.Time
+		}{
+			{time.Date(2024, time.March, 10, 1, 59, 0, 0, newYork), time.Date(2024, time.March, 10, 6, 0, 0, 0, time.UTC)},
+			{time.Date(2024, time.March, 10, 3, 1, 0, 0, newYork), time.Date(2024, time.March, 10, 7, 0, 0, 0, time.UTC)},
+		}
+
+		for _, tt := range tests {
+			hour, err := TestID(tt.local, 0).Hour()
+			if err != nil {
+				t.Fatalf("Hour() error = %v, wantErr %v", err, false)
+			}
+
+			if !hour.Equal(tt.expected) || hour.Location() != time.UTC {
+				t.Fatalf("Hour() = %v, want %v", hour, tt.expected)
+			}
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, err := (*LDID)(nil).Hour(); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("Hour() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestDateString(t *testing.T) {
+	midnight := time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		t        time.Time
+		expected string
+	}{
+		{"Before midnight", midnight.Add(-time.Millisecond), "2024-01-01"},
+		{"At midnight", midnight, "2024-01-02"},
+		{"After midnight", midnight.Add(time.Millisecond), "2024-01-02"},
+		{"Local date ahead of UTC", time.Date(2024, time.January, 2, 8, 0, 0, 0, time.FixedZone("JST", 9*60*60)), "2024-01-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date, err := TestID(tt.t, 0).DateString()
+			if err != nil {
+				t.Fatalf("DateString() error = %v, wantErr %v", err, false)
+			}
+
+			if date != tt.expected {
+				t.Fatalf("DateString() = %v, want %v", date, tt.expected)
+			}
+		})
+	}
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, err := (*LDID)(nil).DateString(); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("DateString() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestPartitionPath(t *testing.T) {
+	ldid, _ := FromString("018cc820-d888-7abc-8000-00000000002a")
+
+	tests := []struct {
+		name     string
+		layout   string
+		expected string
+	}{
+		{"Hourly", "2006/01/02/15", "2024/01/02/03/018cc820-d888-7abc-8000-00000000002a"},
+		{"Trailing slash", "2006/01/02/", "2024/01/02/018cc820-d888-7abc-8000-00000000002a"},
+		{"Hive style", "year=2006/month=01", "year=2024/month=01/018cc820-d888-7abc-8000-00000000002a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := ldid.PartitionPath(tt.layout)
+			if err != nil {
+				t.Fatalf("PartitionPath() error = %v, wantErr %v", err, false)
+			}
+
+			if path != tt.expected {
+				t.Fatalf("PartitionPath() = %v, want %v", path, tt.expected)
+			}
+		})
+	}
+
+	t.Run("Empty layout", func(t *testing.T) {
+		if _, err := ldid.PartitionPath(""); err == nil {
+			t.Fatalf("PartitionPath() error = %v, wantErr %v", err, true)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, err := (*LDID)(nil).PartitionPath("2006"); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("PartitionPath() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestPredatesCutover(t *testing.T) {
+	cutover := time.Date(2024, time.January, 2, 3, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		t        time.Time
+		expected bool
+	}{
+		{"Before", cutover.Add(-time.Millisecond), true},
+		{"At", cutover, false},
+		{"After", cutover.Add(time.Millisecond), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predates, err := TestID(tt.t, 0).PredatesCutover(cutover)
+			if err != nil {
+				t.Fatalf("PredatesCutover() error = %v, wantErr %v", err, false)
+			}
+
+			if predates != tt.expected {
+				t.Fatalf("PredatesCutover() = %v, want %v", predates, tt.expected)
+			}
+		})
+	}
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, err := (*LDID)(nil).PredatesCutover(cutover); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("PredatesCutover() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestExpiredAt(t *testing.T) {
+	created := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+	ldid := TestID(created, 0)
+	ttl := 24 * time.Hour
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected bool
+	}{
+		{"Just inside", created.Add(ttl - time.Millisecond), false},
+		{"At TTL", created.Add(ttl), false},
+		{"Just outside", created.Add(ttl + time.Millisecond), true},
+		{"Created in the future", created.Add(-time.Hour), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expired, err := ldid.ExpiredAt(ttl, tt.now)
+			if err != nil {
+				t.Fatalf("ExpiredAt() error = %v, wantErr %v", err, false)
+			}
+
+			if expired != tt.expected {
+				t.Fatalf("ExpiredAt() = %v, want %v", expired, tt.expected)
+			}
+		})
+	}
+
+	t.Run("Current time", func(t *testing.T) {
+		if expired, _ := ldid.Expired(ttl); !expired {
+			t.Fatalf("Expired() = %v, want %v", expired, true)
+		}
+	})
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, err := (*LDID)(nil).Expired(ttl); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("Expired() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
+
+func TestISOWeek(t *testing.T) {
+	tests := []struct {
+		name         string
+		t            time.Time
+		expectedYear int
+		expectedWeek int
+	}{
+		{"Mid-year", time.Date(2024, time.June, 12, 12, 0, 0, 0, time.UTC), 2024, 24},
+		{"Last Sunday of ISO year", time.Date(2024, time.December, 29, 23, 59, 59, 0, time.UTC), 2024, 52},
+		{"Next ISO year in December", time.Date(2024, time.December, 30, 0, 0, 0, 0, time.UTC), 2025, 1},
+		{"Previous ISO year in January", time.Date(2021, time.January, 3, 0, 0, 0, 0, time.UTC), 2020, 53},
+		{"UTC rather than local week", time.Date(2024, time.December, 30, 8, 0, 0, 0, time.FixedZone("JST", 9*60*60)), 2024, 52},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			year, week, err := TestID(tt.t, 0).ISOWeek()
+			if err != nil {
+				t.Fatalf("ISOWeek() error = %v, wantErr %v", err, false)
+			}
+
+			if year != tt.expectedYear || week != tt.expectedWeek {
+				t.Fatalf("ISOWeek() = %v-W%02d, want %v-W%02d", year, week, tt.expectedYear, tt.expectedWeek)
+			}
+		})
+	}
+
+	t.Run("Nil LDID", func(t *testing.T) {
+		if _, _, err := (*LDID)(nil).ISOWeek(); !errors.Is(err, ErrNilLDID) {
+			t.Fatalf("ISOWeek() error = %v, want %v", err, ErrNilLDID)
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package id
+
+import (
+	"fmt"
+	"io"
+)
+
+// TimeSource is an optional interface a Generator can implement to provide timestamps from a
+// source that can fail, such as a distributed time oracle. NewWithGenerator and the other
+// constructors use it instead of GenerateUnixTimestampMS when available, and return its errors.
+//
+// The interface is found by type assertion, so a Generator that wraps another one must
+// implement NowMillis and forward it to the wrapped generator when that is a TimeSource;
+// otherwise the errors of the wrapped source are lost to GenerateUnixTimestampMS.
+type TimeSource interface {
+	NowMillis() (uint64, error)
+}
+
+// generateTimestamp returns the timestamp for a new LDID from g, preferring its TimeSource.
+func generateTimestamp(g Generator) (uint64, error) {
+	ts, ok := g.(TimeSource)
+	if !ok {
+		return g.GenerateUnixTimestampMS(), nil
+	}
+
+	timestamp, err := ts.NowMillis()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read time: %w", err)
+	}
+
+	return timestamp, nil
+}
+
+// Compile-time check to ensure TimeSourceGenerator implements TimeSource
+var _ TimeSource = &TimeSourceGenerator{}
+
+// TimeSourceGenerator is a Generator that reads timestamps from an external TimeSource
+// instead of the local clock, decoupling the time of an LDID from the wall clock.
+type TimeSourceGenerator struct {
+	// Source provides the Unix timestamps in milliseconds.
+	Source TimeSource
+	// Reader is the source of random data. When nil, crypto/rand.Reader is used.
+	Reader io.Reader
+}
+
+// NowMillis returns the current time from the Source.
+func (g *TimeSourceGenerator) NowMillis() (uint64, error) {
+	return g.Source.NowMillis()
+}
+
+// GenerateUnixTimestampMS returns the current time from the Source, or 0 if it fails. The
+// constructors of this package call NowMillis instead, so they can return the error.
+func (g *TimeSourceGenerator) GenerateUnixTimestampMS() uint64 {
+	timestamp, err := g.Source.NowMillis()
+	if err != nil {
+		return 0
+	}
+
+	return timestamp
+}
+
+func (g *TimeSourceGenerator) GenerateRandomBits(randReader io.Reader, n int64) (uint64, error) {
+	return (&DefaultGenerator{Reader: g.Reader}).GenerateRandomBits(randReader, n)
+}
@@ -0,0 +1,70 @@
+package id
+
+import (
+	"errors"
+	"testing"
+)
+
+// Mocks
+
+type mockTimeSource struct {
+	millis uint64
+	err    error
+}
+
+func (m *mockTimeSource) NowMillis() (uint64, error) {
+	return m.millis, m.err
+}
+
+type bulkTimeSourceGenerator struct {
+	DefaultGenerator
+	mockTimeSource
+}
+
+// Test functions
+
+func TestTimeSourceGenerator(t *testing.T) {
+	t.Run("Oracle time", func(t *testing.T) {
+		g := &TimeSourceGenerator{Source: &mockTimeSource{millis: 1704164645000}}
+
+		ldid, err := NewWithGenerator(g)
+		if err != nil {
+			t.Fatalf("NewWithGenerator() error = %v, wantErr %v", err, false)
+		}
+
+		if timestamp, _ := ldid.Timestamp(); timestamp != 1704164645000 {
+			t.Fatalf("Timestamp() = %v, want %v", timestamp, 1704164645000)
+		}
+	})
+
+	t.Run("Failing oracle", func(t *testing.T) {
+		mockErr := errors.New("mock error")
+		g := &TimeSourceGenerator{Source: &mockTimeSource{err: mockErr}}
+
+		if _, err := NewWithGenerator(g); !errors.Is(err, mockErr) {
+			t.Fatalf("NewWithGenerator() error = %v, want %v", err, mockErr)
+		}
+
+		if _, err := NewWithLayout(g, DefaultLayout); !errors.Is(err, mockErr) {
+			t.Fatalf("NewWithLayout() error = %v, want %v", err, mockErr)
+		}
+
+		f, _ := NewFactory(WithGenerator(g))
+		if _, err := f.New(); !errors.Is(err, mockErr) {
+			t.Fatalf("Factory.New() error = %v, want %v", err, mockErr)
+		}
+
+		if timestamp := g.GenerateUnixTimestampMS(); timestamp != 0 {
+			t.Fatalf("GenerateUnixTimestampMS() = %v, want %v", timestamp, 0)
+		}
+	})
+
+	t.Run("Failing oracle in batch", func(t *testing.T) {
+		mockErr := errors.New("mock error")
+		g := &bulkTimeSourceGenerator{mockTimeSource: mockTimeSource{err: mockErr}}
+
+		if _, err := NewBatchWithGenerator(g, 2); !errors.Is(err, mockErr) {
+			t.Fatalf("NewBatchWithGenerator() error = %v, want %v", err, mockErr)
+		}
+	})
+}
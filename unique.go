@@ -0,0 +1,35 @@
+package id
+
+import (
+	"errors"
+	"fmt"
+)
+
+// NewUnique creates a new LDID, calling exists to check it against an external uniqueness
+// constraint and regenerating while exists reports true, up to maxTries attempts.
+//
+// Collisions between randomly generated LDIDs are astronomically unlikely; the hook mainly
+// exists to keep retry loops out of calling code and to exercise them in tests.
+func NewUnique(exists func(*LDID) (bool, error), maxTries int) (*LDID, error) {
+	if maxTries < 1 {
+		return &LDID{}, errors.New("failed to create unique LDID: maxTries must be at least 1")
+	}
+
+	for i := 0; i < maxTries; i++ {
+		id, err := New()
+		if err != nil {
+			return &LDID{}, err
+		}
+
+		taken, err := exists(id)
+		if err != nil {
+			return &LDID{}, fmt.Errorf("failed to create unique LDID: %w", err)
+		}
+
+		if !taken {
+			return id, nil
+		}
+	}
+
+	return &LDID{}, fmt.Errorf("failed to create unique LDID: all %d attempts already exist", maxTries)
+}
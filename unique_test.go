@@ -0,0 +1,66 @@
+package id
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewUnique(t *testing.T) {
+	t.Run("Retry once", func(t *testing.T) {
+		calls := 0
+		exists := func(*LDID) (bool, error) {
+			calls++
+			return calls == 1, nil
+		}
+
+		ldid, err := NewUnique(exists, 3)
+		if err != nil {
+			t.Fatalf("NewUnique() error = %v, wantErr %v", err, false)
+		}
+
+		if ldid == nil {
+			t.Fatalf("NewUnique() = %v, want non-nil", ldid)
+		}
+
+		if calls != 2 {
+			t.Fatalf("exists() calls = %v, want %v", calls, 2)
+		}
+	})
+
+	t.Run("Attempts exhausted", func(t *testing.T) {
+		calls := 0
+		exists := func(*LDID) (bool, error) {
+			calls++
+			return true, nil
+		}
+
+		if _, err := NewUnique(exists, 3); err == nil {
+			t.Fatalf("NewUnique() error = %v, wantErr true", err)
+		}
+
+		if calls != 3 {
+			t.Fatalf("exists() calls = %v, want %v", calls, 3)
+		}
+	})
+
+	t.Run("Exists failing", func(t *testing.T) {
+		mockErr := errors.New("mock error")
+		exists := func(*LDID) (bool, error) {
+			return false, mockErr
+		}
+
+		if _, err := NewUnique(exists, 3); !errors.Is(err, mockErr) {
+			t.Fatalf("NewUnique() error = %v, want %v", err, mockErr)
+		}
+	})
+
+	t.Run("Invalid maxTries", func(t *testing.T) {
+		exists := func(*LDID) (bool, error) {
+			return false, nil
+		}
+
+		if _, err := NewUnique(exists, 0); err == nil {
+			t.Fatalf("NewUnique() error = %v, wantErr true", err)
+		}
+	})
+}